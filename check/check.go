@@ -0,0 +1,199 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package check
+
+import (
+	"fmt"
+	"github.com/DataDrake/static-cling/config"
+	"github.com/DataDrake/static-cling/content"
+	"github.com/DataDrake/static-cling/file"
+	"github.com/DataDrake/static-cling/templates"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Problem is a single issue found while checking a project
+type Problem struct {
+	Path    string
+	Message string
+}
+
+// String describes this Problem
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Path, p.Message)
+}
+
+// Problems is a list of every Problem found in a project
+type Problems []Problem
+
+// add records a new Problem
+func (ps *Problems) add(path, format string, args ...interface{}) {
+	*ps = append(*ps, Problem{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Project loads the configuration, templates, and content of a project and checks them for consistency
+func Project(src string, opts config.Options) (problems Problems) {
+	dir := config.Path(src)
+	conf, err := config.Load(dir, opts)
+	if err != nil {
+		problems.config(dir, err)
+	}
+	themes, err := conf.ThemeSources()
+	if err != nil {
+		problems.add(dir, "failed to find themes, reason: %s", err)
+	}
+	tmplDir := filepath.Join(src, "templates")
+	if tmpls, err := templates.Load(src, conf.URL, themes...); err != nil {
+		problems.add(tmplDir, "failed to load templates, reason: %s", err)
+	} else {
+		problems.templates(&conf, tmpls)
+	}
+	contentDir := filepath.Join(src, "content")
+	if tree, err := content.NewTree(contentDir); err != nil {
+		problems.add(contentDir, "failed to read content, reason: %s", err)
+	} else {
+		problems.content(&conf, tree, content.Options{
+			Reading: conf.Reading,
+			Lenient: opts.Lenient,
+		})
+	}
+	return
+}
+
+// config records each problem found while loading the configuration
+func (ps *Problems) config(dir string, err error) {
+	errs, ok := err.(config.Errors)
+	if !ok {
+		errs = config.Errors{err}
+	}
+	for _, e := range errs {
+		if de, ok := e.(*config.DecodeError); ok {
+			ps.add(de.Location(), "%s", de.Message)
+			continue
+		}
+		ps.add(dir, "failed to load configuration, reason: %s", e)
+	}
+}
+
+// templates checks that every template named by the configuration exists
+func (ps *Problems) templates(conf *config.Site, tmpls *templates.Tree) {
+	if _, err := tmpls.Root.Get("layout"); err != nil {
+		ps.add(tmpls.Root.Path, "missing the site \"layout\" template")
+	}
+	for _, section := range conf.Sections.List() {
+		path := section.Path
+		dir, err := tmpls.Section(section.Key)
+		if err != nil {
+			ps.add(path, "no template directory for section %q", section.Key)
+			continue
+		}
+		names := map[string]string{
+			"content":  section.Templates.Content,
+			"category": section.Templates.Category,
+		}
+		for i, listing := range section.Templates.Listings {
+			names[fmt.Sprintf("listings[%d]", i)] = listing
+		}
+		for _, field := range sortedKeys(names) {
+			name := names[field]
+			if name == "" {
+				if field == "content" || (field == "category" && len(section.Categories) > 0) {
+					ps.add(path, "section %q has no %s template", section.Key, field)
+				}
+				continue
+			}
+			if _, err := dir.Get(name); err != nil {
+				ps.add(path, "%s template %q not found in %q", field, name, dir.Path)
+			}
+		}
+	}
+}
+
+// content checks that every content directory has a section, and that every Page can be loaded and is categorized
+func (ps *Problems) content(conf *config.Site, tree *content.Tree, opts content.Options) {
+	for _, name := range sortedDirs(tree.Root.Subs) {
+		sub := tree.Root.Subs[name]
+		section, ok := conf.Sections[name]
+		if !ok {
+			ps.add(sub.Path, "no section configuration for content directory %q", name)
+		}
+		ps.pages(conf, section, sub, opts)
+	}
+}
+
+// pages checks every Page in a directory of a section, recursively, switching to nested sections as they are found
+func (ps *Problems) pages(conf *config.Site, section *config.Section, dir *content.Dir, opts content.Options) {
+	for _, name := range sortedFiles(dir.Files) {
+		f := dir.Files[name]
+		if content.IsMeta(f) {
+			continue
+		}
+		page, err := content.NewPage(f, opts)
+		if err == content.ErrUnsupportedContent {
+			continue
+		}
+		if err != nil {
+			ps.add(f.Path(), "failed to load page, reason: %s", err)
+			continue
+		}
+		if _, ok := conf.Authors[page.Author]; page.Author != "" && !ok {
+			ps.add(f.Path(), "author %q is not declared in the site configuration", page.Author)
+		}
+		if section != nil && page.Category != "" && section.Category(page.Category) == nil {
+			ps.add(f.Path(), "category %q is not declared in section %q", page.Category, section.Key)
+		}
+	}
+	for _, name := range sortedDirs(dir.Subs) {
+		sub := dir.Subs[name]
+		if nested, ok := conf.Sections[strings.Trim(sub.URL, "/")]; ok {
+			ps.pages(conf, nested, sub, opts)
+			continue
+		}
+		ps.pages(conf, section, sub, opts)
+	}
+}
+
+// sortedKeys provides the keys of a map in sorted order
+func sortedKeys(m map[string]string) (keys []string) {
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
+
+// sortedDirs provides the names of content directories in sorted order
+func sortedDirs(m map[string]*content.Dir) (names []string) {
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+// sortedFiles provides the names of files in sorted order
+func sortedFiles(m map[string]*file.File) (names []string) {
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
@@ -0,0 +1,142 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package check
+
+import (
+	"github.com/DataDrake/static-cling/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// project is the smallest project which passes every check, by path relative to its root
+var project = map[string]string{
+	"config/_site.yaml": "name: Test\nthemes:\n    - starter\n",
+	"config/docs.yaml": `name: Docs
+templates:
+    content: page.html
+    category: topic.html
+    listings:
+        - list.html
+categories:
+    - name: Guides
+`,
+	"templates/docs/page.html":  "page",
+	"templates/docs/topic.html": "topic",
+	"templates/docs/list.html":  "list",
+	"content/index.html":        "<p>home</p>",
+	"content/docs/intro.html":   "<p>intro</p>",
+	"content/docs/intro.yaml":   "title: Intro\ncategory: Guides\n",
+}
+
+// writeProject writes the files of a project, with any changes, to a temporary directory
+func writeProject(t *testing.T, changes map[string]string) string {
+	dir := t.TempDir()
+	files := make(map[string]string)
+	for name, contents := range project {
+		files[name] = contents
+	}
+	for name, contents := range changes {
+		files[name] = contents
+	}
+	for name, contents := range files {
+		if contents == "" {
+			continue
+		}
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestProject(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes map[string]string
+		path    string
+		message string
+	}{
+		{
+			name:    "missing content template",
+			changes: map[string]string{"templates/docs/page.html": ""},
+			path:    "config/docs.yaml",
+			message: `content template "page.html" not found`,
+		},
+		{
+			name:    "missing listing template",
+			changes: map[string]string{"templates/docs/list.html": ""},
+			path:    "config/docs.yaml",
+			message: `listings[0] template "list.html" not found`,
+		},
+		{
+			name:    "missing category template",
+			changes: map[string]string{"templates/docs/topic.html": ""},
+			path:    "config/docs.yaml",
+			message: `category template "topic.html" not found`,
+		},
+		{
+			name: "no category template",
+			changes: map[string]string{
+				"config/docs.yaml": "name: Docs\ntemplates:\n    content: page.html\ncategories:\n    - name: Guides\n",
+			},
+			path:    "config/docs.yaml",
+			message: `section "docs" has no category template`,
+		},
+		{
+			name:    "undeclared category",
+			changes: map[string]string{"content/docs/intro.yaml": "title: Intro\ncategory: Recipes\n"},
+			path:    "content/docs/intro.html",
+			message: `category "Recipes" is not declared in section "docs"`,
+		},
+		{
+			name:    "bad sidecar",
+			changes: map[string]string{"content/docs/intro.yaml": "title: [Intro\n"},
+			path:    "content/docs/intro.html",
+			message: "failed to load page",
+		},
+		{
+			name:    "content without a section",
+			changes: map[string]string{"content/blog/hello.html": "<p>hello</p>"},
+			path:    "content/blog",
+			message: `no section configuration for content directory "blog"`,
+		},
+	}
+	if problems := Project(writeProject(t, nil), config.Options{}); len(problems) != 0 {
+		t.Errorf("expected no problems, found: %v", problems)
+	}
+	for _, test := range tests {
+		dir := writeProject(t, test.changes)
+		problems := Project(dir, config.Options{})
+		if len(problems) != 1 {
+			t.Errorf("%s: found %d problems, want 1: %v", test.name, len(problems), problems)
+			continue
+		}
+		path, err := filepath.Rel(dir, problems[0].Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if filepath.ToSlash(path) != test.path || !strings.Contains(problems[0].Message, test.message) {
+			t.Errorf("%s: problem = %s, want %s: %s", test.name, problems[0], test.path, test.message)
+		}
+	}
+}
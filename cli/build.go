@@ -20,9 +20,13 @@ import (
 	"fmt"
 	"github.com/DataDrake/cli-ng/v2/cmd"
 	"github.com/DataDrake/static-cling/config"
+	"github.com/DataDrake/static-cling/content"
+	"github.com/DataDrake/static-cling/file"
 	"github.com/DataDrake/static-cling/render"
 	"github.com/DataDrake/static-cling/templates"
+	"github.com/DataDrake/static-cling/util"
 	log "github.com/DataDrake/waterlog"
+	"path/filepath"
 )
 
 func init() {
@@ -43,33 +47,109 @@ var Build = cmd.Sub{
 
 // BuildFlags are flags used by the "build" sub-command
 type BuildFlags struct {
-	Src   string `short:"S" long:"source" desc:"source of project files (default '.')"`
-	Build string `short:"B" long:"build"  desc:"name of the buid dir, relative to source (default 'build')"`
+	Src     string `short:"S" long:"source" desc:"source of project files (default '.')"`
+	Build   string `short:"B" long:"build"  desc:"name of the buid dir, relative to source (default 'build')"`
+	Strict  bool   `long:"strict" desc:"fail the build if there are broken internal links"`
+	Lenient bool   `long:"lenient" desc:"ignore unknown keys in configuration and page metadata"`
+	Env     string `short:"e" long:"env" desc:"name of the environment overlay in 'config/_env' to apply"`
 }
 
 // BuildRun carries out the "build" sub-command
 func BuildRun(r *cmd.Root, s *cmd.Sub) {
 	// gFlags := r.Flags.(*GlobalFlags)
 	flags := s.Flags.(*BuildFlags)
+	opts := config.Options{
+		Env:     flags.Env,
+		Lenient: flags.Lenient,
+	}
+	broken, err := buildSite(flags.Src, filepath.Join(flags.Src, flags.Build), opts)
+	if err != nil {
+		log.Fatalf("Failed to %s\n", err)
+	}
+	for _, link := range broken {
+		log.Warnln(link.String())
+	}
+	if len(broken) > 0 {
+		if flags.Strict {
+			log.Fatalf("Found %d broken links\n", len(broken))
+		}
+		log.Warnf("Found %d broken links\n", len(broken))
+		return
+	}
+	log.Goodln("No broken links.")
+}
+
+// buildSite renders a project into a build directory, copies its assets and checks the internal links
+//
+// Errors describe the step which failed, eg. "load templates: ...".
+func buildSite(srcDir, buildDir string, opts config.Options) (broken []render.BrokenLink, err error) {
 	log.Infoln("Loading configuration")
-	conf, err := config.Load(config.Path(flags.Src))
+	conf, err := config.Load(config.Path(srcDir), opts)
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %q\n", err)
+		err = fmt.Errorf("load configuration:\n%w", err)
+		return
 	}
 	fmt.Printf("%#v\n", conf)
 	log.Goodln("Config Loaded.")
 
 	log.Infoln("Loading templates")
-	tmpls, err := templates.Load(flags.Src)
+	themes, err := conf.ThemeSources()
+	if err != nil {
+		err = fmt.Errorf("find themes:\n%w", err)
+		return
+	}
+	tmpls, err := templates.Load(srcDir, conf.URL, themes...)
 	if err != nil {
-		log.Fatalf("Failed to load templates: %q\n", err)
+		err = fmt.Errorf("load templates: %w", err)
+		return
 	}
 	fmt.Printf("%#v\n", tmpls)
 	log.Goodln("Templates Loaded.")
 	log.Infoln("Setting up the rendering process")
 	site, err := render.NewSite(&conf, tmpls)
 	if err != nil {
-		log.Fatalf("Failed to set up rendering: %q\n", err)
+		err = fmt.Errorf("set up rendering: %w", err)
+		return
 	}
 	fmt.Printf("%#v\n", site)
+
+	log.Infoln("Loading content")
+	src, err := content.Load(file.Source{Path: filepath.Join(srcDir, "content")}, content.Options{
+		Reading: conf.Reading,
+		Lenient: opts.Lenient,
+	})
+	if err != nil {
+		err = fmt.Errorf("load content: %w", err)
+		return
+	}
+	log.Goodln("Content Loaded.")
+	util.CreateDir(buildDir)
+	dst, err := content.NewTree(buildDir)
+	if err != nil {
+		err = fmt.Errorf("read build directory: %w", err)
+		return
+	}
+	log.Infoln("Rendering site")
+	if err = site.Render(src, dst, true); err != nil {
+		err = fmt.Errorf("render site: %w", err)
+		return
+	}
+	log.Goodln("Site Rendered.")
+	log.Infoln("Copying assets")
+	for i := len(themes) - 1; i >= 0; i-- {
+		assets, err := themes[i].Join(templates.AssetDir).Sub()
+		if err != nil {
+			return nil, fmt.Errorf("read theme assets: %w", err)
+		}
+		util.CopyFS(assets, buildDir)
+	}
+	assetDir := filepath.Join(srcDir, templates.AssetDir)
+	util.CopyDir(assetDir, buildDir)
+	log.Goodln("Assets Copied.")
+
+	log.Infoln("Checking internal links")
+	if broken, err = render.NewLinkChecker(&conf, buildDir, assetDir).Check(site.Outputs); err != nil {
+		err = fmt.Errorf("check links: %w", err)
+	}
+	return
 }
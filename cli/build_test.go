@@ -0,0 +1,90 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cli
+
+import (
+	"errors"
+	"github.com/DataDrake/static-cling/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildSiteErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, config.Dir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	raw := []byte("name: Test\nthemes:\n    - starter\nnmae: typo\n")
+	if err := os.WriteFile(filepath.Join(dir, config.Dir, config.SiteName+".yaml"), raw, 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := buildSite(dir, filepath.Join(dir, "build"), config.Options{})
+	var errs config.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("error = %v, want config.Errors", err)
+	}
+	var de *config.DecodeError
+	if !errors.As(errs[0], &de) || de.Line != 4 {
+		t.Errorf("error = %v, want the unknown key on line 4", errs[0])
+	}
+}
+
+func TestBuildNestedSections(t *testing.T) {
+	dir := t.TempDir()
+	blankStarter.with(scaffold{
+		"config/docs.yaml":             "name: Docs\ntemplates:\n    content: post.html\n    listings:\n        - list.html\n        - sublist.html\n",
+		"config/docs/api.yaml":         "name: API\n",
+		"templates/docs/post.html":     "docs post",
+		"templates/docs/list.html":     "list {{.Section.Name}}:{{range .Pages}} {{.URL}}{{end}}",
+		"templates/docs/sublist.html":  "sublist:{{range .Pages}} {{.URL}}{{end}}",
+		"templates/docs/api/post.html": "api post",
+		"content/docs/intro.html":      "<p>intro</p>",
+		"content/docs/api/ref.html":    "<p>ref</p>",
+		"content/docs/extra/page.html": "<p>page</p>",
+	}).write(dir)
+	buildDir := filepath.Join(dir, "build")
+	broken, err := buildSite(dir, buildDir, config.Options{})
+	if err != nil {
+		t.Fatalf("failed to build: %s", err)
+	}
+	for _, link := range broken {
+		t.Errorf("broken link: %s", link)
+	}
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"docs/index.html", "list Docs: /docs/intro.html"},
+		{"docs/intro.html", "docs post"},
+		{"docs/api/index.html", "list API: /docs/api/ref.html"},
+		{"docs/api/ref.html", "api post"},
+		{"docs/extra/index.html", "sublist: /docs/extra/page.html"},
+		{"docs/extra/page.html", "docs post"},
+	}
+	for _, test := range tests {
+		raw, err := os.ReadFile(filepath.Join(buildDir, filepath.FromSlash(test.path)))
+		if err != nil {
+			t.Errorf("missing %s: %s", test.path, err)
+			continue
+		}
+		if !strings.Contains(string(raw), test.want) {
+			t.Errorf("%s does not contain %q:\n%s", test.path, test.want, raw)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cli
+
+import (
+	"github.com/DataDrake/cli-ng/v2/cmd"
+	"github.com/DataDrake/static-cling/check"
+	"github.com/DataDrake/static-cling/config"
+	log "github.com/DataDrake/waterlog"
+)
+
+func init() {
+	cmd.Register(&Check)
+}
+
+// Check validates a project without generating any output
+var Check = cmd.Sub{
+	Name:  "check",
+	Alias: "ck",
+	Short: "Validate the configuration, templates, and content of a project",
+	Flags: &CheckFlags{
+		Src: ".",
+	},
+	Run: CheckRun,
+}
+
+// CheckFlags are flags used by the "check" sub-command
+type CheckFlags struct {
+	Src     string `short:"S" long:"source" desc:"source of project files (default '.')"`
+	Lenient bool   `long:"lenient" desc:"ignore unknown keys in configuration and page metadata"`
+	Env     string `short:"e" long:"env" desc:"name of the environment overlay in 'config/_env' to apply"`
+}
+
+// CheckRun carries out the "check" sub-command
+func CheckRun(r *cmd.Root, s *cmd.Sub) {
+	flags := s.Flags.(*CheckFlags)
+	opts := config.Options{
+		Env:     flags.Env,
+		Lenient: flags.Lenient,
+	}
+	log.Infof("Checking project '%s'\n", flags.Src)
+	problems := check.Project(flags.Src, opts)
+	for _, problem := range problems {
+		log.Errorln(problem.String())
+	}
+	if len(problems) > 0 {
+		log.Fatalf("Found %d problems\n", len(problems))
+	}
+	log.Goodln("No problems found.")
+}
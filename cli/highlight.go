@@ -0,0 +1,74 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cli
+
+import (
+	"bytes"
+	"github.com/DataDrake/cli-ng/v2/cmd"
+	"github.com/DataDrake/static-cling/content"
+	"github.com/DataDrake/static-cling/templates"
+	"github.com/DataDrake/static-cling/util"
+	log "github.com/DataDrake/waterlog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func init() {
+	cmd.Register(&Highlight)
+}
+
+// Highlight generates the stylesheet for syntax highlighting
+var Highlight = cmd.Sub{
+	Name:  "highlight",
+	Alias: "hl",
+	Short: "Generate the stylesheet used by highlighted code",
+	Flags: &HighlightFlags{
+		Src: ".",
+	},
+	Args: &HighlightArgs{},
+	Run:  HighlightRun,
+}
+
+// HighlightFlags are flags used by the "highlight" sub-command
+type HighlightFlags struct {
+	Src string `short:"S" long:"source" desc:"source of project files (default '.')"`
+}
+
+// HighlightArgs are arguments used by the "highlight" sub-command
+type HighlightArgs struct {
+	Style string `desc:"name of the highlighting style (eg. 'monokai')"`
+}
+
+// HighlightRun carries out the "highlight" sub-command
+func HighlightRun(r *cmd.Root, s *cmd.Sub) {
+	flags := s.Flags.(*HighlightFlags)
+	args := s.Args.(*HighlightArgs)
+	var css bytes.Buffer
+	if err := content.HighlightCSS(&css, args.Style); err != nil {
+		log.Errorf("Failed to generate stylesheet, reason: %s\n", err)
+		log.Fatalf("Available styles: %s\n", strings.Join(content.HighlightStyles(), ", "))
+	}
+	dir := filepath.Join(flags.Src, templates.AssetDir, "css")
+	util.CreateDir(dir)
+	path := filepath.Join(dir, "highlight.css")
+	log.Infof("Writing %q style to '%s'\n", args.Style, path)
+	if err := os.WriteFile(path, css.Bytes(), 0644); err != nil {
+		log.Fatalf("Failed to write stylesheet '%s', reason: %s\n", path, err)
+	}
+	log.Goodln("Done.")
+}
@@ -23,19 +23,21 @@ import (
 	log "github.com/DataDrake/waterlog"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 func init() {
 	cmd.Register(&Init)
 }
 
-// Init populates a new blank project tree
+// Init creates a new project from one of the starters, ready to build
 var Init = cmd.Sub{
 	Name:  "init",
-	Alias: "new",
-	Short: "Create a new static-cling project",
+	Alias: "in",
+	Short: "Create a new static-cling project from a starter (blank, blog, or docs)",
 	Flags: &InitFlags{
 		DestDir: ".",
+		Starter: "blog",
 	},
 	Run: InitRun,
 }
@@ -43,6 +45,8 @@ var Init = cmd.Sub{
 // InitFlags are flags used by the "init" sub-command
 type InitFlags struct {
 	DestDir string `short:"D" long:"destdir" desc:"destination for new project (default is '.')"`
+	Starter string `short:"t" long:"starter" desc:"starter to create the project from: blank, blog, or docs (default is 'blog')"`
+	Force   bool   `short:"f" long:"force" desc:"overwrite existing files"`
 }
 
 // InitRun carries out the "init" sub-command
@@ -50,13 +54,22 @@ func InitRun(r *cmd.Root, s *cmd.Sub) {
 	// gFlags := r.Flags.(*GlobalFlags)
 	flags := s.Flags.(*InitFlags)
 	// args := s.Args.(*InitArgs)
+	files, ok := starters[flags.Starter]
+	if !ok {
+		log.Fatalf("Unknown starter %q, expected one of: %s\n", flags.Starter, strings.Join(starterNames(), ", "))
+	}
+	if existing := files.existing(flags.DestDir); len(existing) > 0 && !flags.Force {
+		log.Fatalf("Refusing to overwrite existing files, use '--force' to replace them:\n    %s\n", strings.Join(existing, "\n    "))
+	}
 	log.Infof("Creating project directory '%s'\n", flags.DestDir)
 	if err := os.MkdirAll(flags.DestDir, 0755); err != nil {
 		log.Fatalf("Failed to create project directory '%s', reason: %s\n", flags.DestDir, err)
 	}
-	log.Goodln("Done.")
 	util.CreateDir(filepath.Join(flags.DestDir, "assets"))
 	util.CreateDir(filepath.Join(flags.DestDir, config.Dir))
 	util.CreateDir(filepath.Join(flags.DestDir, "content"))
 	util.CreateDir(filepath.Join(flags.DestDir, "templates"))
+	log.Infof("Writing the %q starter\n", flags.Starter)
+	files.write(flags.DestDir)
+	log.Goodln("Done. Run 'static-cling build' to render the site.")
 }
@@ -0,0 +1,169 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cli
+
+import (
+	"bytes"
+	"github.com/DataDrake/cli-ng/v2/cmd"
+	"github.com/DataDrake/static-cling/config"
+	"github.com/DataDrake/static-cling/templates"
+	log "github.com/DataDrake/waterlog"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+func init() {
+	cmd.Register(&New)
+}
+
+// New creates a new page of content from the archetype for its section
+var New = cmd.Sub{
+	Name:  "new",
+	Alias: "nw",
+	Short: "Create new content from an archetype, eg. 'new roadmap/v1-1-0'",
+	Flags: &NewFlags{
+		Src: ".",
+	},
+	Args: &NewArgs{},
+	Run:  NewRun,
+}
+
+// NewFlags are flags used by the "new" sub-command
+type NewFlags struct {
+	Src    string `short:"S" long:"source" desc:"source of project files (default '.')"`
+	Title  string `short:"t" long:"title" desc:"title of the new page (default is taken from its name)"`
+	Author string `short:"a" long:"author" desc:"key of the author of the new page (default is the only configured author)"`
+	Force  bool   `short:"f" long:"force" desc:"overwrite existing files"`
+}
+
+// NewArgs are arguments used by the "new" sub-command
+type NewArgs struct {
+	Path string `desc:"section and name of the new page (eg. 'roadmap/v1-1-0')"`
+}
+
+// archetypeData is made available to the templates of an Archetype
+type archetypeData struct {
+	Title   string
+	Slug    string
+	Author  string
+	Date    time.Time
+	Section *config.Section
+	Site    *config.Site
+}
+
+// titleFromSlug turns the name of a page into a reasonable default title
+//
+// Runs of numbers are treated as versions (eg. "v1-1-0" becomes "v1.1.0") and the first word is
+// capitalized unless it holds a number (eg. "hello-world" becomes "Hello world").
+func titleFromSlug(slug string) string {
+	var words []string
+	for _, part := range strings.Split(slug, "-") {
+		if n := len(words); n > 0 && isNumber(part) && endsInDigit(words[n-1]) {
+			words[n-1] += "." + part
+			continue
+		}
+		words = append(words, part)
+	}
+	if words[0] != "" && strings.IndexFunc(words[0], unicode.IsDigit) < 0 {
+		r, size := utf8.DecodeRuneInString(words[0])
+		words[0] = string(unicode.ToUpper(r)) + words[0][size:]
+	}
+	return strings.Join(words, " ")
+}
+
+// isNumber checks if a string is made up only of digits
+func isNumber(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) }) < 0
+}
+
+// endsInDigit checks if the last character of a string is a digit
+func endsInDigit(s string) bool {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return unicode.IsDigit(r)
+}
+
+// NewRun carries out the "new" sub-command
+func NewRun(r *cmd.Root, s *cmd.Sub) {
+	flags := s.Flags.(*NewFlags)
+	args := s.Args.(*NewArgs)
+	key, slug := path.Split(strings.Trim(filepath.ToSlash(args.Path), "/"))
+	key = strings.TrimSuffix(key, "/")
+	if key == "" || slug == "" {
+		// "new" used to create a project, before it was renamed to "init"
+		log.Fatalf("Expected '<section>/<name>', found %q. To create a new project, use 'static-cling init' instead.\n", args.Path)
+	}
+	conf, err := config.Load(config.Path(flags.Src), config.Options{})
+	if err != nil {
+		log.Fatalf("Failed to load configuration:\n%s\n", err)
+	}
+	section, ok := conf.Sections[key]
+	if !ok {
+		log.Fatalf("Unknown section %q, expected one of: %s\n", key, strings.Join(sectionKeys(conf.Sections), ", "))
+	}
+	data := archetypeData{
+		Title:   flags.Title,
+		Slug:    slug,
+		Author:  flags.Author,
+		Date:    time.Now(),
+		Section: section,
+		Site:    &conf,
+	}
+	if data.Title == "" {
+		data.Title = titleFromSlug(slug)
+	}
+	if data.Author == "" && len(conf.Authors) == 1 {
+		for author := range conf.Authors {
+			data.Author = author
+		}
+	}
+	if len(conf.Authors) > 0 && data.Author != "" {
+		if _, ok := conf.Authors[data.Author]; !ok {
+			log.Fatalf("Unknown author %q\n", data.Author)
+		}
+	}
+	archetype, err := templates.LoadArchetype(flags.Src, key, conf.URL)
+	if err != nil {
+		log.Fatalf("Failed to load archetype for %q, reason: %s\n", key, err)
+	}
+	log.Infof("Creating %q from the %q archetype\n", args.Path, archetype.Path)
+	files := make(scaffold)
+	for _, ext := range archetype.Exts() {
+		var buff bytes.Buffer
+		if err = archetype.Execute(&buff, ext, data); err != nil {
+			log.Fatalf("Failed to fill in the %q archetype, reason: %s\n", archetype.Path, err)
+		}
+		files[slug+ext] = buff.String()
+	}
+	dir := filepath.Join(flags.Src, "content", filepath.FromSlash(key))
+	if existing := files.existing(dir); len(existing) > 0 && !flags.Force {
+		log.Fatalf("Refusing to overwrite existing files, use '--force' to replace them:\n    %s\n", strings.Join(existing, "\n    "))
+	}
+	files.write(dir)
+	log.Goodln("Done.")
+}
+
+// sectionKeys lists the key of every section, in order
+func sectionKeys(sections config.Sections) (keys []string) {
+	for _, section := range sections.List() {
+		keys = append(keys, section.Key)
+	}
+	return
+}
@@ -0,0 +1,40 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cli
+
+import (
+	"testing"
+)
+
+func TestTitleFromSlug(t *testing.T) {
+	tests := []struct {
+		slug, want string
+	}{
+		{"hello-world", "Hello world"},
+		{"v1-1-0", "v1.1.0"},
+		{"release-1-2", "Release 1.2"},
+		{"top-10-tips", "Top 10 tips"},
+		{"2021-in-review", "2021 in review"},
+		{"épée", "Épée"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		if got := titleFromSlug(test.slug); got != test.want {
+			t.Errorf("titleFromSlug(%q) = %q, want %q", test.slug, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cli
+
+import (
+	"github.com/DataDrake/static-cling/util"
+	log "github.com/DataDrake/waterlog"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// scaffold is a set of files to create, keyed by their slash-separated path
+type scaffold map[string]string
+
+// write creates each of the files in a scaffold inside of a directory
+func (s scaffold) write(dir string) {
+	var names []string
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		util.CreateDir(filepath.Dir(path))
+		log.Infof("Writing '%s'\n", path)
+		if err := os.WriteFile(path, []byte(s[name]), 0644); err != nil {
+			log.Fatalf("Failed to write '%s', reason: %s\n", path, err)
+		}
+	}
+}
+
+// with creates a copy of a scaffold with more files, replacing any with the same path
+func (s scaffold) with(extra scaffold) scaffold {
+	merged := make(scaffold)
+	for name, contents := range s {
+		merged[name] = contents
+	}
+	for name, contents := range extra {
+		merged[name] = contents
+	}
+	return merged
+}
+
+// existing lists the files of a scaffold which are already present inside of a directory
+func (s scaffold) existing(dir string) (paths []string) {
+	for name := range s {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if _, err := os.Stat(path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return
+}
@@ -0,0 +1,112 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cli
+
+import (
+	"github.com/DataDrake/cli-ng/v2/cmd"
+	"github.com/DataDrake/static-cling/config"
+	"github.com/DataDrake/static-cling/content"
+	"github.com/DataDrake/static-cling/schema"
+	"github.com/DataDrake/static-cling/util"
+	log "github.com/DataDrake/waterlog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func init() {
+	cmd.Register(&Schema)
+}
+
+// Schema generates JSON Schemas for configuration and metadata files
+var Schema = cmd.Sub{
+	Name:  "schema",
+	Alias: "js",
+	Short: "Generate JSON Schemas for configuration and page metadata",
+	Flags: &SchemaFlags{},
+	Args:  &SchemaArgs{},
+	Run:   SchemaRun,
+}
+
+// SchemaFlags are flags used by the "schema" sub-command
+type SchemaFlags struct {
+	Output string `short:"o" long:"output" desc:"write each schema to '<kind>.schema.json' in this dir, instead of printing it"`
+}
+
+// SchemaArgs are arguments used by the "schema" sub-command
+type SchemaArgs struct {
+	Kinds []string `zero:"yes" desc:"kinds of file to describe (default: all of them)"`
+}
+
+// schemaKinds are the values described by each kind of schema
+var schemaKinds = map[string]interface{}{
+	"site":      config.Site{},
+	"section":   config.Section{},
+	"templates": config.Templates{},
+	"category":  config.Category{},
+	"env":       config.Overlay{},
+	"variables": config.Variables{},
+	"page":      content.Page{},
+}
+
+// SchemaRun carries out the "schema" sub-command
+func SchemaRun(r *cmd.Root, s *cmd.Sub) {
+	flags := s.Flags.(*SchemaFlags)
+	args := s.Args.(*SchemaArgs)
+	kinds := args.Kinds
+	if len(kinds) == 0 {
+		for kind := range schemaKinds {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+	}
+	var available []string
+	for kind := range schemaKinds {
+		available = append(available, kind)
+	}
+	sort.Strings(available)
+	for _, kind := range kinds {
+		if _, ok := schemaKinds[kind]; !ok {
+			log.Fatalf("Unknown kind of schema %q, available: %s\n", kind, strings.Join(available, ", "))
+		}
+	}
+	if flags.Output == "" {
+		if len(kinds) != 1 {
+			log.Fatalln("Only one schema may be printed at a time, use '--output' to write several")
+		}
+		if err := schema.For(schemaKinds[kinds[0]]).Write(os.Stdout); err != nil {
+			log.Fatalf("Failed to write schema, reason: %s\n", err)
+		}
+		return
+	}
+	util.CreateDir(flags.Output)
+	for _, kind := range kinds {
+		path := filepath.Join(flags.Output, kind+".schema.json")
+		log.Infof("Writing %q schema to '%s'\n", kind, path)
+		f, err := os.Create(path)
+		if err != nil {
+			log.Fatalf("Failed to create schema '%s', reason: %s\n", path, err)
+		}
+		err = schema.For(schemaKinds[kind]).Write(f)
+		f.Close()
+		if err != nil {
+			log.Fatalf("Failed to write schema '%s', reason: %s\n", path, err)
+		}
+	}
+	log.Goodln("Done.")
+}
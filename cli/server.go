@@ -18,7 +18,13 @@ package cli
 
 import (
 	"github.com/DataDrake/cli-ng/v2/cmd"
+	"github.com/DataDrake/static-cling/render"
+	log "github.com/DataDrake/waterlog"
+	"io"
 	"net/http"
+	"os"
+	"path"
+	"path/filepath"
 )
 
 func init() {
@@ -30,10 +36,51 @@ var Server = cmd.Sub{
 	Name:  "server",
 	Alias: "run",
 	Short: "Start and HTTP server to serve out the files in the output directory",
-	Run:   ServerRun,
+	Flags: &ServerFlags{
+		Src:   ".",
+		Build: "build",
+	},
+	Run: ServerRun,
+}
+
+// ServerFlags are flags used by the "server" sub-command
+type ServerFlags struct {
+	Src   string `short:"S" long:"source" desc:"source of project files (default '.')"`
+	Build string `short:"B" long:"build"  desc:"name of the buid dir, relative to source (default 'build')"`
 }
 
 // ServerRun carries out the "server" sub-command
 func ServerRun(r *cmd.Root, s *cmd.Sub) {
-	http.ListenAndServe(":8080", http.FileServer(http.Dir("build")))
+	flags := s.Flags.(*ServerFlags)
+	root := http.Dir(filepath.Join(flags.Src, flags.Build))
+	log.Infof("Serving '%s' on http://localhost:8080\n", root)
+	if err := http.ListenAndServe(":8080", notFoundHandler{root}); err != nil {
+		log.Fatalf("Server stopped, reason: %s\n", err)
+	}
+}
+
+// notFoundHandler serves files from the build directory, using the generated 404 page for missing files
+type notFoundHandler struct {
+	root http.FileSystem
+}
+
+// ServeHTTP responds to a single request (satisfies http.Handler)
+func (h notFoundHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f, err := h.root.Open(path.Clean("/" + r.URL.Path))
+	if err == nil {
+		f.Close()
+	}
+	if !os.IsNotExist(err) {
+		http.FileServer(h.root).ServeHTTP(w, r)
+		return
+	}
+	page, err := h.root.Open("/" + render.NotFoundName)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer page.Close()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusNotFound)
+	io.Copy(w, page)
 }
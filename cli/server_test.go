@@ -0,0 +1,54 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNotFoundHandler(t *testing.T) {
+	build := fstest.MapFS{
+		"index.html": {Data: []byte("home")},
+		"about.html": {Data: []byte("about")},
+		"404.html":   {Data: []byte("not found")},
+	}
+	tests := []struct {
+		root   fstest.MapFS
+		path   string
+		status int
+		body   string
+	}{
+		{build, "/", http.StatusOK, "home"},
+		{build, "/about.html", http.StatusOK, "about"},
+		{build, "/missing.html", http.StatusNotFound, "not found"},
+		{build, "/missing/", http.StatusNotFound, "not found"},
+		{fstest.MapFS{}, "/missing.html", http.StatusNotFound, "404 page not found\n"},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		notFoundHandler{http.FS(test.root)}.ServeHTTP(w, httptest.NewRequest("GET", test.path, nil))
+		if w.Code != test.status {
+			t.Errorf("%s: status = %d, want %d", test.path, w.Code, test.status)
+		}
+		if body := w.Body.String(); body != test.body {
+			t.Errorf("%s: body = %q, want %q", test.path, body, test.body)
+		}
+	}
+}
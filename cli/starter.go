@@ -0,0 +1,136 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cli
+
+import (
+	"sort"
+)
+
+// blankStarter is the smallest project that can be built, shared by every other starter
+//
+// The layout, 404 and root page templates come from the builtin "starter" theme.
+var blankStarter = scaffold{
+	"config/_site.yaml": `name: My Site
+themes:
+    - starter
+`,
+	"content/index.html": `<h1>Welcome</h1>
+<p>This page lives in 'content/index.html'. Run 'static-cling build' to render the site.</p>
+`,
+}
+
+// blogStarter adds a section of dated posts, listed newest first
+var blogStarter = blankStarter.with(scaffold{
+	"config/_site.yaml": `name: My Blog
+themes:
+    - starter
+authors:
+    me:
+        name: Your Name
+        bio: Writes things down.
+`,
+	"config/posts.yaml": `name: Posts
+weight: 10
+templates:
+    content: post.html
+    listings:
+        - index.html
+menu:
+    name: main
+`,
+	"templates/posts/index.html": `<h1>{{.Section.Name}}</h1>
+{{range .Pages.Latest}}
+<article>
+    <h2><a href="{{.URL}}">{{.Title}}</a></h2>
+    <p>{{.Date.Format "January 2, 2006"}} &mdash; {{.ReadingTime}} min read</p>
+    <p>{{.Summary}}{{if .Truncated}} &hellip;{{end}}</p>
+</article>
+{{end}}
+`,
+	"templates/posts/post.html": `<h1>{{.Page.Title}}</h1>
+<p>{{.Page.Date.Format "January 2, 2006"}} &mdash; {{.Page.ReadingTime}} min read</p>
+{{.Page.Content}}
+`,
+	"content/index.html": `<h1>Welcome</h1>
+<p>Read the latest <a href="/posts/">posts</a>.</p>
+`,
+	"content/posts/hello-world.html": `<p>This is your first post. Edit it in 'content/posts/hello-world.html'.</p>
+`,
+	"content/posts/hello-world.yaml": `title: Hello, World
+author: me
+date: 2021-01-01T00:00:00Z
+`,
+})
+
+// docsStarter adds a section of pages, each with a table of contents
+var docsStarter = blankStarter.with(scaffold{
+	"config/_site.yaml": `name: My Docs
+themes:
+    - starter
+`,
+	"content/index.html": `<h1>Welcome</h1>
+<p>Start with the <a href="/docs/">documentation</a>.</p>
+`,
+	"config/docs.yaml": `name: Docs
+weight: 10
+templates:
+    content: page.html
+menu:
+    name: main
+`,
+	"templates/docs/page.html": `{{define "toc"}}
+<ul>
+    {{range .}}
+    <li>
+        <a href="#{{.ID}}">{{.Title}}</a>
+        {{if .Children}}{{template "toc" .Children}}{{end}}
+    </li>
+    {{end}}
+</ul>
+{{end}}
+<h1>{{.Page.Title}}</h1>
+{{if .Page.TOC}}
+<nav class="toc">
+    {{template "toc" .Page.TOC}}
+</nav>
+{{end}}
+{{.Page.Content}}
+`,
+	"content/docs/index.html": `<h2 id="getting-started">Getting Started</h2>
+<p>This page lives in 'content/docs/index.html'.</p>
+<h2 id="next-steps">Next Steps</h2>
+<p>Add more pages alongside it to grow the documentation.</p>
+`,
+	"content/docs/index.yaml": `title: Getting Started
+`,
+})
+
+// starters are the projects which "init" can create, by name
+var starters = map[string]scaffold{
+	"blank": blankStarter,
+	"blog":  blogStarter,
+	"docs":  docsStarter,
+}
+
+// starterNames lists every starter, in order
+func starterNames() (names []string) {
+	for name := range starters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
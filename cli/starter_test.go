@@ -0,0 +1,46 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cli
+
+import (
+	"github.com/DataDrake/static-cling/config"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStarters(t *testing.T) {
+	for _, name := range starterNames() {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			starters[name].write(dir)
+			buildDir := filepath.Join(dir, "build")
+			broken, err := buildSite(dir, buildDir, config.Options{})
+			if err != nil {
+				t.Fatalf("failed to build: %s", err)
+			}
+			for _, link := range broken {
+				t.Errorf("broken link: %s", link)
+			}
+			for _, page := range []string{"index.html", "404.html"} {
+				if _, err := os.Stat(filepath.Join(buildDir, page)); err != nil {
+					t.Errorf("missing %s: %s", page, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cli
+
+import (
+	"github.com/DataDrake/cli-ng/v2/cmd"
+	"github.com/DataDrake/static-cling/config"
+	"github.com/DataDrake/static-cling/file"
+	"github.com/DataDrake/static-cling/themes"
+	"github.com/DataDrake/static-cling/util"
+	log "github.com/DataDrake/waterlog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func init() {
+	cmd.Register(&Theme)
+}
+
+// Theme manages the themes of a project
+var Theme = cmd.Sub{
+	Name:  "theme",
+	Alias: "th",
+	Short: "Manage themes, eg. 'theme new <name>' to create one",
+	Flags: &ThemeFlags{
+		Src: ".",
+	},
+	Args: &ThemeArgs{},
+	Run:  ThemeRun,
+}
+
+// ThemeFlags are flags used by the "theme" sub-command
+type ThemeFlags struct {
+	Src string `short:"S" long:"source" desc:"source of project files (default '.')"`
+}
+
+// ThemeArgs are arguments used by the "theme" sub-command
+type ThemeArgs struct {
+	Action string `desc:"what to do with the theme (eg. 'new')"`
+	Name   string `desc:"name of the theme"`
+}
+
+// starterTheme is the builtin theme copied by "theme new"
+const starterTheme = "starter"
+
+// validThemeName checks that a theme name refers to a theme in ThemesDir, rather than some other path
+func validThemeName(name string) bool {
+	switch {
+	case name == "", name == ".", name == "..":
+		return false
+	case strings.ContainsAny(name, `/\`), file.IsArchive(name):
+		return false
+	}
+	return true
+}
+
+// ThemeRun carries out the "theme" sub-command
+func ThemeRun(r *cmd.Root, s *cmd.Sub) {
+	flags := s.Flags.(*ThemeFlags)
+	args := s.Args.(*ThemeArgs)
+	if args.Action != "new" {
+		log.Fatalf("Unknown theme action %q, expected 'new'\n", args.Action)
+	}
+	if !validThemeName(args.Name) {
+		log.Fatalf("Invalid theme name %q, expected a bare name without path separators\n", args.Name)
+	}
+	dir := filepath.Join(flags.Src, config.ThemesDir, args.Name)
+	if _, err := os.Stat(dir); err == nil {
+		log.Fatalf("Theme '%s' already exists\n", dir)
+	}
+	log.Infof("Creating theme %q in '%s' from the builtin %q theme\n", args.Name, dir, starterTheme)
+	starter, ok := themes.Get(starterTheme)
+	if !ok {
+		log.Fatalf("Missing the builtin %q theme\n", starterTheme)
+	}
+	fsys, err := starter.Sub()
+	if err != nil {
+		log.Fatalf("Failed to read the builtin %q theme, reason: %s\n", starterTheme, err)
+	}
+	util.CreateDir(dir)
+	util.CopyFS(fsys, dir)
+	siteFile := filepath.Join(dir, config.Dir, config.SiteName+".yaml")
+	raw, err := os.ReadFile(siteFile)
+	if err != nil {
+		log.Fatalf("Failed to read '%s', reason: %s\n", siteFile, err)
+	}
+	raw = []byte(strings.ReplaceAll(string(raw), "theme: "+starterTheme, "theme: "+args.Name))
+	if err = os.WriteFile(siteFile, raw, 0644); err != nil {
+		log.Fatalf("Failed to write '%s', reason: %s\n", siteFile, err)
+	}
+	log.Goodf("Done. Add %q to the 'themes' of '%s' to use it.\n", args.Name, config.SiteName+".yaml")
+}
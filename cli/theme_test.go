@@ -0,0 +1,43 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cli
+
+import (
+	"testing"
+)
+
+func TestValidThemeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"plain", true},
+		{"my-theme.v2", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"../escape", false},
+		{"nested/theme", false},
+		{`nested\theme`, false},
+		{"theme.zip", false},
+	}
+	for _, test := range tests {
+		if got := validThemeName(test.name); got != test.want {
+			t.Errorf("validThemeName(%q) = %t, want %t", test.name, got, test.want)
+		}
+	}
+}
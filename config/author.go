@@ -0,0 +1,43 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+// Author describes a person who writes content for the Site
+type Author struct {
+	Name   string `yaml:"name"`
+	Bio    string `yaml:"bio"`
+	Avatar string `yaml:"avatar"`
+	Links  []Link `yaml:"links"`
+	Key    string `yaml:"-"`
+}
+
+// Link is a named external URL
+type Link struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+	Icon string `yaml:"icon"`
+}
+
+// Authors is a map of Author profiles, by the key used to reference them from content
+type Authors map[string]*Author
+
+// setKeys records the key of each Author in its profile
+func (as Authors) setKeys() {
+	for key, author := range as {
+		author.Key = key
+	}
+}
@@ -0,0 +1,147 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	log "github.com/DataDrake/waterlog"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DataDir is the relative directory for data files
+const DataDir = "data"
+
+// DataPath gets the path of the data directory which sits alongside a configuration directory
+func DataPath(dir string) string {
+	return filepath.Join(filepath.Dir(filepath.Clean(dir)), DataDir)
+}
+
+// ErrUnsupportedData indicates that a file in the data directory is not in a supported format
+var ErrUnsupportedData = errors.New("data file has unsupported extension")
+
+// ErrDuplicateData indicates that more than one data file or directory provides the same key
+var ErrDuplicateData = errors.New("data key is provided more than once")
+
+// Data is a tree of values read from the data directory, keyed by directory and file name
+type Data map[string]interface{}
+
+// loadData recursively reads every supported data file in a directory
+//
+// A directory and a file, or files in different formats, with the same name are reported as ErrDuplicateData.
+func loadData(dir string) (data Data, modified time.Time, err error) {
+	data = make(Data)
+	paths := make(map[string]string)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	log.Debugf("Loading data files from %q\n", dir)
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(dir, name)
+		var value interface{}
+		var latest time.Time
+		if entry.IsDir() {
+			value, latest, err = loadData(path)
+		} else {
+			ext := filepath.Ext(name)
+			name = strings.TrimSuffix(name, ext)
+			value, latest, err = loadDataFile(path, ext)
+		}
+		if err == ErrUnsupportedData {
+			log.Warnf("Skipping unsupported data file %q\n", path)
+			err = nil
+			continue
+		}
+		if err != nil {
+			return
+		}
+		if previous, ok := paths[name]; ok {
+			err = fmt.Errorf("%w: %q in '%s' and '%s'", ErrDuplicateData, name, previous, path)
+			return
+		}
+		paths[name] = path
+		if latest.After(modified) {
+			modified = latest
+		}
+		data[name] = value
+	}
+	return
+}
+
+// loadDataFile reads a single data file, using its extension to determine the format
+func loadDataFile(path, ext string) (value interface{}, modified time.Time, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return
+	}
+	modified = info.ModTime()
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		err = yaml.NewDecoder(f).Decode(&value)
+	case ".json":
+		err = json.NewDecoder(f).Decode(&value)
+	case ".toml":
+		var table map[string]interface{}
+		_, err = toml.NewDecoder(f).Decode(&table)
+		value = table
+	case ".csv":
+		value, err = readCSV(f)
+	default:
+		err = ErrUnsupportedData
+	}
+	if err == io.EOF {
+		err = nil
+	}
+	return
+}
+
+// readCSV converts a CSV file into a list of records, keyed by the names in the header row
+func readCSV(r io.Reader) (records []map[string]string, err error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil || len(rows) == 0 {
+		return
+	}
+	header := rows[0]
+	for _, row := range rows[1:] {
+		record := make(map[string]string)
+		for i, column := range header {
+			if i < len(row) {
+				record[column] = row[i]
+			}
+		}
+		records = append(records, record)
+	}
+	return
+}
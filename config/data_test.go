@@ -0,0 +1,71 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDataDuplicates(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+	}{
+		{"directory and file", []string{"team/alice.yaml", "team.yaml"}},
+		{"two formats", []string{"team.json", "team.yaml"}},
+	}
+	for _, test := range tests {
+		dir := t.TempDir()
+		for _, name := range test.files {
+			path := filepath.Join(dir, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, _, err := loadData(dir); !errors.Is(err, ErrDuplicateData) {
+			t.Errorf("%s: error = %v, want %v", test.name, err, ErrDuplicateData)
+		}
+	}
+}
+
+func TestLoadDataNested(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "team"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "team", "alice.yaml"), []byte("role: lead\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data, _, err := loadData(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	team, ok := data["team"].(Data)
+	if !ok {
+		t.Fatalf("team = %#v, want Data", data["team"])
+	}
+	alice, ok := team["alice"].(map[string]interface{})
+	if !ok || alice["role"] != "lead" {
+		t.Errorf("alice = %#v, want role lead", team["alice"])
+	}
+}
@@ -0,0 +1,247 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/DataDrake/static-cling/schema"
+	"gopkg.in/yaml.v3"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DecodeError is a single problem found while decoding a file
+type DecodeError struct {
+	Path    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// Location describes the path, line and column of this DecodeError, as far as they are known
+func (e *DecodeError) Location() string {
+	switch {
+	case e.Line == 0:
+		return e.Path
+	case e.Column == 0:
+		return fmt.Sprintf("%s:%d", e.Path, e.Line)
+	default:
+		return fmt.Sprintf("%s:%d:%d", e.Path, e.Line, e.Column)
+	}
+}
+
+// Error describes the location and cause of this DecodeError (satisfies error)
+func (e *DecodeError) Error() string {
+	return e.Location() + ": " + e.Message
+}
+
+// DecodeErrors is every problem found while decoding a single file
+type DecodeErrors []*DecodeError
+
+// Error lists every DecodeError, one per line (satisfies error)
+func (es DecodeErrors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Errors is every problem found while loading the configuration, across any number of files
+type Errors []error
+
+// Error lists every error, one per line (satisfies error)
+func (es Errors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// add records an error, flattening DecodeErrors and Errors so that each problem is listed once
+func (es *Errors) add(err error) {
+	switch e := err.(type) {
+	case nil:
+	case DecodeErrors:
+		for _, de := range e {
+			*es = append(*es, de)
+		}
+	case Errors:
+		*es = append(*es, e...)
+	default:
+		*es = append(*es, err)
+	}
+}
+
+// err provides these Errors as a single error, or nil if there are none
+func (es Errors) err() error {
+	if len(es) == 0 {
+		return nil
+	}
+	return es
+}
+
+// linePattern matches the line number prefix of errors from the YAML decoder
+var linePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// fieldPattern matches an unknown key in errors from the YAML decoder
+var fieldPattern = regexp.MustCompile(`^field (\S+) not found in type (\S+)$`)
+
+// Decode reads a document into a value, after validating it against the Schema for the value
+//
+// The format is chosen by the extension of the path. Unknown keys are rejected unless lenient is
+// set. Errors are returned as DecodeErrors, with the path, line and column of each problem.
+func Decode(path string, r io.Reader, v interface{}, lenient bool) error {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	root, err := parse(path, raw)
+	if err != nil {
+		return err
+	}
+	return decodeNode(path, root, v, lenient)
+}
+
+// validate checks a parsed document against the Schema for a value
+func validate(path string, root *yaml.Node, v interface{}, lenient bool) error {
+	invalid := schema.For(v).Validate(root, !lenient)
+	if len(invalid) == 0 {
+		return nil
+	}
+	var errs DecodeErrors
+	for _, e := range invalid {
+		errs = append(errs, &DecodeError{
+			Path:    path,
+			Line:    e.Line,
+			Column:  e.Column,
+			Message: e.Message,
+		})
+	}
+	return errs
+}
+
+// decodeNode validates a parsed document and then reads it into a value
+//
+// The Schema catches most problems with useful messages, while strict decoding rejects any unknown
+// keys the Schema missed. Merged documents have no source text, so they are re-encoded for the
+// decoder and any errors are traced back to the original nodes.
+func decodeNode(path string, root *yaml.Node, v interface{}, lenient bool) error {
+	if root == nil || root.Kind == 0 {
+		// empty document
+		return nil
+	}
+	if err := validate(path, root, v, lenient); err != nil {
+		return err
+	}
+	raw, err := yaml.Marshal(root)
+	if err != nil {
+		return DecodeErrors{newDecodeError(path, root, nil, err.Error())}
+	}
+	var encoded yaml.Node
+	if err = yaml.Unmarshal(raw, &encoded); err != nil {
+		return DecodeErrors{newDecodeError(path, root, nil, err.Error())}
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(!lenient)
+	err = dec.Decode(v)
+	if err == nil || err == io.EOF {
+		return nil
+	}
+	terr, ok := err.(*yaml.TypeError)
+	if !ok {
+		return DecodeErrors{newDecodeError(path, root, &encoded, err.Error())}
+	}
+	var errs DecodeErrors
+	for _, msg := range terr.Errors {
+		errs = append(errs, newDecodeError(path, root, &encoded, msg))
+	}
+	return errs
+}
+
+// newDecodeError converts an error message from the YAML decoder, finding the position of the offending node if possible
+//
+// Line numbers in the message refer to the encoded document, if there is one, and are mapped back to
+// the same node in root. Otherwise they refer to root itself.
+func newDecodeError(path string, root, encoded *yaml.Node, msg string) *DecodeError {
+	e := &DecodeError{
+		Path:    path,
+		Message: msg,
+	}
+	match := linePattern.FindStringSubmatch(msg)
+	if match == nil {
+		return e
+	}
+	line, _ := strconv.Atoi(match[1])
+	e.Message = match[2]
+	var key string
+	if field := fieldPattern.FindStringSubmatch(e.Message); field != nil {
+		key = field[1]
+		e.Message = fmt.Sprintf("unknown key %q", key)
+	}
+	if encoded == nil {
+		e.Line = line
+		if node := findNode(root, line, key); node != nil {
+			e.Column = node.Column
+		}
+		return e
+	}
+	if encoded.Kind == yaml.DocumentNode && root.Kind != yaml.DocumentNode && len(encoded.Content) > 0 {
+		encoded = encoded.Content[0]
+	}
+	if node := sameNode(encoded, root, findNode(encoded, line, key)); node != nil {
+		e.Line = node.Line
+		e.Column = node.Column
+	}
+	return e
+}
+
+// sameNode finds the node of "b" in the same position as "target" is in "a", where both have the same structure
+func sameNode(a, b, target *yaml.Node) *yaml.Node {
+	if a == nil || b == nil || target == nil {
+		return nil
+	}
+	if a == target {
+		return b
+	}
+	if len(a.Content) != len(b.Content) {
+		return nil
+	}
+	for i := range a.Content {
+		if found := sameNode(a.Content[i], b.Content[i], target); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// findNode searches for the first node on a line, optionally matching a specific value
+func findNode(node *yaml.Node, line int, value string) *yaml.Node {
+	if node.Line == line && (value == "" || node.Value == value) && node.Kind == yaml.ScalarNode {
+		return node
+	}
+	for _, child := range node.Content {
+		if found := findNode(child, line, value); found != nil {
+			return found
+		}
+	}
+	return nil
+}
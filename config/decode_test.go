@@ -0,0 +1,91 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"github.com/DataDrake/static-cling/schema"
+	"gopkg.in/yaml.v3"
+	"strings"
+	"testing"
+)
+
+// loose describes itself with a Schema that allows anything, leaving strict decoding as the only check
+type loose struct {
+	Name string `yaml:"name"`
+}
+
+// JSONSchema allows any value (satisfies schema.Schemer)
+func (loose) JSONSchema() *schema.Schema {
+	return &schema.Schema{}
+}
+
+// looseParent holds a loose value, so that its unknown keys are nested
+type looseParent struct {
+	Title string `yaml:"title"`
+	Inner loose  `yaml:"inner"`
+}
+
+func TestDecodeKnownFields(t *testing.T) {
+	raw := "title: x\ninner:\n    name: y\n    extra: z\n"
+	tests := []struct {
+		lenient bool
+		want    string
+	}{
+		{false, `test.yaml:4:5: unknown key "extra"`},
+		{true, ""},
+	}
+	for _, test := range tests {
+		var v looseParent
+		err := Decode("test.yaml", strings.NewReader(raw), &v, test.lenient)
+		switch {
+		case test.want == "" && err != nil:
+			t.Errorf("lenient: %t, unexpected error: %s", test.lenient, err)
+		case test.want != "" && (err == nil || err.Error() != test.want):
+			t.Errorf("lenient: %t, error = %v, want %q", test.lenient, err, test.want)
+		}
+	}
+}
+
+func TestDecodeMergedPositions(t *testing.T) {
+	base, err := parse("base.yaml", []byte("title: x\n\n\ninner:\n    name: y\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	over, err := parse("over.yaml", []byte("inner:\n    extra: z\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := mergeNodes(base.Content[0], over.Content[0])
+	var v looseParent
+	err = decodeNode("base.yaml", root, &v, false)
+	want := `base.yaml:2:5: unknown key "extra"`
+	if err == nil || err.Error() != want {
+		t.Errorf("error = %v, want %q", err, want)
+	}
+}
+
+func TestDecodeSchema(t *testing.T) {
+	var v Section
+	err := Decode("docs.yaml", strings.NewReader("name: Docs\nweight: heavy\n"), &v, false)
+	if err == nil || !strings.HasPrefix(err.Error(), "docs.yaml:2:9: ") {
+		t.Errorf("error = %v, want a problem at docs.yaml:2:9", err)
+	}
+	var node yaml.Node
+	if err = decodeNode("empty.yaml", &node, &v, false); err != nil {
+		t.Errorf("empty document: unexpected error: %s", err)
+	}
+}
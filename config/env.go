@@ -0,0 +1,255 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DataDrake/static-cling/file"
+	log "github.com/DataDrake/waterlog"
+	"gopkg.in/yaml.v3"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// EnvDir is the subdirectory of the configuration directory which holds environment overlays
+const EnvDir = "_env"
+
+// ErrUnknownEnv indicates that there is no overlay for the selected environment
+var ErrUnknownEnv = errors.New("no configuration found for environment")
+
+// Overlay is the configuration for an environment, deep-merged over the Site and Section configurations
+type Overlay struct {
+	Site     `yaml:",inline"`
+	Sections map[string]Section `yaml:"sections"`
+}
+
+// overlay is a parsed Overlay, kept as YAML nodes so that it can be merged before decoding
+type overlay struct {
+	path     string
+	site     *yaml.Node
+	sections *yaml.Node
+	modified time.Time
+}
+
+// loadOverlay reads the overlay for the environment selected by the Options, if there is one
+func loadOverlay(dir string, opts Options) (o *overlay, err error) {
+	if opts.Env == "" {
+		return
+	}
+	path, err := FindFile(filepath.Join(dir, EnvDir), opts.Env)
+	if err != nil {
+		return
+	}
+	log.Debugf("Loading environment configuration %q\n", path)
+	root, modified, err := readFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = fmt.Errorf("%w %q: %s", ErrUnknownEnv, opts.Env, path)
+		}
+		return
+	}
+	o = &overlay{
+		path:     path,
+		modified: modified,
+	}
+	if root == nil {
+		return
+	}
+	if err = interpolate(path, root); err != nil {
+		return
+	}
+	if err = validate(path, root, &Overlay{}, opts.Lenient); err != nil {
+		return
+	}
+	// split the sections from the rest of the overlay, which applies to the Site
+	o.site = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if key.Value == "sections" {
+			o.sections = value
+			continue
+		}
+		o.site.Content = append(o.site.Content, key, value)
+	}
+	return
+}
+
+// section gets the overlay for a single Section, if there is one
+func (o *overlay) section(key string) *yaml.Node {
+	if o == nil || o.sections == nil {
+		return nil
+	}
+	return mappingValue(o.sections, key)
+}
+
+// checkSections reports any overlay for a Section which does not exist
+func (o *overlay) checkSections(confs Sections) error {
+	if o == nil || o.sections == nil {
+		return nil
+	}
+	var errs DecodeErrors
+	for i := 0; i+1 < len(o.sections.Content); i += 2 {
+		key := o.sections.Content[i]
+		if _, ok := confs[key.Value]; !ok {
+			errs = append(errs, &DecodeError{
+				Path:    o.path,
+				Line:    key.Line,
+				Column:  key.Column,
+				Message: fmt.Sprintf("unknown section %q", key.Value),
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// readFile parses a configuration file, returning the top-level value and its modification time
+//
+// The value will be nil if the file is empty.
+func readFile(path string) (root *yaml.Node, modified time.Time, err error) {
+	return readFileFS(nil, path)
+}
+
+// readFileFS parses a configuration file in an fs.FS, or on disk if it is nil (see readFile)
+func readFileFS(fsys fs.FS, path string) (root *yaml.Node, modified time.Time, err error) {
+	f, err := file.Open(fsys, path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return
+	}
+	modified = info.ModTime()
+	raw, err := io.ReadAll(f)
+	if err != nil {
+		return
+	}
+	if root, err = parse(path, raw); err != nil {
+		return
+	}
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return nil, modified, nil
+		}
+		root = root.Content[0]
+	}
+	if root.Kind == 0 {
+		root = nil
+	}
+	return
+}
+
+// mappingValue finds the value for a key of a mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// mergeNodes deep-merges one YAML node over another
+//
+// Mappings are merged key by key, while any other value in the overlay replaces the original.
+func mergeNodes(dst, src *yaml.Node) *yaml.Node {
+	if dst == nil {
+		return src
+	}
+	if src == nil {
+		return dst
+	}
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		return src
+	}
+	merged := *dst
+	merged.Content = append([]*yaml.Node(nil), dst.Content...)
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		found := false
+		for j := 0; j+1 < len(merged.Content); j += 2 {
+			if merged.Content[j].Value == key.Value {
+				merged.Content[j+1] = mergeNodes(merged.Content[j+1], value)
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged.Content = append(merged.Content, key, value)
+		}
+	}
+	return &merged
+}
+
+// envPattern matches a reference to an environment variable, like "${HOME}"
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// interpolate replaces references to environment variables in every value of a YAML node
+//
+// Unquoted values are resolved again afterwards, so a variable may also provide a number or boolean.
+func interpolate(path string, node *yaml.Node) error {
+	var errs DecodeErrors
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 1; i < len(node.Content); i += 2 {
+				walk(node.Content[i])
+			}
+		case yaml.SequenceNode, yaml.DocumentNode:
+			for _, child := range node.Content {
+				walk(child)
+			}
+		case yaml.ScalarNode:
+			if !envPattern.MatchString(node.Value) {
+				return
+			}
+			node.Value = envPattern.ReplaceAllStringFunc(node.Value, func(ref string) string {
+				name := envPattern.FindStringSubmatch(ref)[1]
+				value, ok := os.LookupEnv(name)
+				if !ok {
+					errs = append(errs, &DecodeError{
+						Path:    path,
+						Line:    node.Line,
+						Column:  node.Column,
+						Message: fmt.Sprintf("environment variable %q is not set", name),
+					})
+				}
+				return value
+			})
+			if node.Style == 0 {
+				node.Tag = ""
+			}
+		}
+	}
+	if node != nil {
+		walk(node)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
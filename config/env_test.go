@@ -0,0 +1,145 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeConfig creates the files of a configuration directory, by slash-separated path
+func writeConfig(t *testing.T, files map[string]string) string {
+	dir := filepath.Join(t.TempDir(), Dir)
+	for name, raw := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(raw), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// interpolated holds values which are set from environment variables
+type interpolated struct {
+	Name  string `yaml:"name"`
+	Count int    `yaml:"count"`
+	Label string `yaml:"label"`
+}
+
+func TestInterpolate(t *testing.T) {
+	os.Setenv("SC_TEST_NAME", "Docs")
+	os.Setenv("SC_TEST_COUNT", "5")
+	os.Unsetenv("SC_TEST_UNSET")
+	defer os.Unsetenv("SC_TEST_NAME")
+	defer os.Unsetenv("SC_TEST_COUNT")
+	root, err := parse("test.yaml", []byte("name: ${SC_TEST_NAME} site\ncount: ${SC_TEST_COUNT}\nlabel: \"${SC_TEST_COUNT}\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = interpolate("test.yaml", root); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var v interpolated
+	if err = decodeNode("test.yaml", root, &v, false); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// the unquoted number is resolved again, while the quoted one remains a string
+	want := interpolated{Name: "Docs site", Count: 5, Label: "5"}
+	if v != want {
+		t.Errorf("decoded = %#v, want %#v", v, want)
+	}
+	root, err = parse("test.yaml", []byte("name: x\nlabel: ${SC_TEST_UNSET}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = interpolate("test.yaml", root)
+	errs, ok := err.(DecodeErrors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("error = %v, want a single DecodeError", err)
+	}
+	if got, want := errs[0].Error(), `test.yaml:2:8: environment variable "SC_TEST_UNSET" is not set`; got != want {
+		t.Errorf("error = %q, want %q", got, want)
+	}
+}
+
+func TestLoadOverlayMissing(t *testing.T) {
+	dir := writeConfig(t, map[string]string{"_site.yaml": "name: Site\n"})
+	o, err := loadOverlay(dir, Options{})
+	if o != nil || err != nil {
+		t.Errorf("no environment: overlay = %v, error = %v, want neither", o, err)
+	}
+	if _, err = loadOverlay(dir, Options{Env: "staging"}); !errors.Is(err, ErrUnknownEnv) {
+		t.Errorf("error = %v, want %v", err, ErrUnknownEnv)
+	}
+}
+
+func TestLoadOverlayMerge(t *testing.T) {
+	dir := writeConfig(t, map[string]string{
+		"_site.yaml": "name: Site\nurl: https://example.com\nreading:\n    wpm: 250\n    cjk: true\n",
+		"blog.yaml":  "name: Blog\ntemplates:\n    content: post\n    listings:\n        - index\n",
+		"_env/staging.yaml": "url: https://staging.example.com\nreading:\n    cjk: false\n" +
+			"sections:\n    blog:\n        templates:\n            content: draft\n",
+	})
+	conf, err := Load(dir, Options{Env: "staging"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	switch {
+	case conf.Env != "staging":
+		t.Errorf("env = %q, want %q", conf.Env, "staging")
+	case conf.Name != "Site" || conf.URL != "https://staging.example.com":
+		t.Errorf("name = %q, url = %q, want the overlay url only", conf.Name, conf.URL)
+	case conf.Reading.WordsPerMinute != 250 || conf.Reading.CJK:
+		t.Errorf("reading = %#v, want wpm 250 kept and cjk disabled", conf.Reading)
+	}
+	blog := conf.Sections["blog"]
+	if blog == nil {
+		t.Fatal("missing section \"blog\"")
+	}
+	if blog.Name != "Blog" || blog.Templates.Content != "draft" || len(blog.Templates.Listings) != 1 {
+		t.Errorf("blog = %#v, want the content template replaced and the rest kept", blog)
+	}
+	conf, err = Load(dir, Options{})
+	if err != nil {
+		t.Fatalf("no environment: unexpected error: %s", err)
+	}
+	if conf.URL != "https://example.com" || conf.Sections["blog"].Templates.Content != "post" {
+		t.Errorf("no environment: url = %q, want the overlay ignored", conf.URL)
+	}
+}
+
+func TestOverlayUnknownSection(t *testing.T) {
+	dir := writeConfig(t, map[string]string{
+		"_site.yaml":        "name: Site\n",
+		"blog.yaml":         "name: Blog\n",
+		"_env/staging.yaml": "sections:\n    blog:\n        weight: 1\n    docs:\n        weight: 2\n",
+	})
+	_, err := Load(dir, Options{Env: "staging"})
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("error = %v, want a single problem", err)
+	}
+	want := filepath.Join(dir, EnvDir, "staging.yaml") + `:4:5: unknown section "docs"`
+	if got := errs[0].Error(); got != want {
+		t.Errorf("error = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/DataDrake/static-cling/file"
+	"gopkg.in/yaml.v3"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Extensions are the supported formats for configuration and metadata, in order of preference
+var Extensions = []string{".yaml", ".yml", ".toml", ".json"}
+
+var (
+	// ErrUnsupportedConfig indicates that a configuration file has an unsupported extension
+	ErrUnsupportedConfig = errors.New("configuration file has unsupported extension")
+	// ErrDuplicateConfig indicates that the same configuration exists in more than one format
+	ErrDuplicateConfig = errors.New("configuration exists in more than one format")
+)
+
+// IsConfig checks if a file extension is one of the supported Extensions
+func IsConfig(ext string) bool {
+	for _, supported := range Extensions {
+		if ext == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// FindFile gets the path of a configuration file in any supported format, defaulting to YAML if there is none
+func FindFile(dir, name string) (path string, err error) {
+	return FindFileFS(nil, dir, name)
+}
+
+// FindFileFS gets the path of a configuration file in an fs.FS, or on disk if it is nil (see FindFile)
+func FindFileFS(fsys fs.FS, dir, name string) (path string, err error) {
+	var found []string
+	for _, ext := range Extensions {
+		candidate := file.Join(fsys, dir, name+ext)
+		if _, err = file.Stat(fsys, candidate); err == nil {
+			found = append(found, candidate)
+			continue
+		}
+		if !os.IsNotExist(err) {
+			return
+		}
+	}
+	err = nil
+	switch len(found) {
+	case 0:
+		path = file.Join(fsys, dir, name+Extensions[0])
+	case 1:
+		path = found[0]
+	default:
+		err = duplicateConfig(found)
+	}
+	return
+}
+
+// duplicateConfig creates an error listing each format of the same configuration
+func duplicateConfig(paths []string) error {
+	return fmt.Errorf("%w: %s", ErrDuplicateConfig, strings.Join(paths, ", "))
+}
+
+// tomlPrefix matches the location at the start of errors from the TOML parser
+var tomlPrefix = regexp.MustCompile(`^toml: line \d+( \(last key "[^"]*"\))?: `)
+
+// parse reads a file into a YAML document, according to its extension
+//
+// JSON is a subset of YAML and keeps its line numbers, but TOML is converted without them.
+func parse(path string, raw []byte) (root *yaml.Node, err error) {
+	root = &yaml.Node{}
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml", ".json":
+		if err = yaml.Unmarshal(raw, root); err != nil {
+			err = DecodeErrors{newDecodeError(path, root, nil, err.Error())}
+		}
+	case ".toml":
+		var values map[string]interface{}
+		if _, err = toml.Decode(string(raw), &values); err != nil {
+			if perr, ok := err.(toml.ParseError); ok {
+				err = DecodeErrors{&DecodeError{
+					Path:    path,
+					Line:    perr.Position.Line,
+					Message: tomlPrefix.ReplaceAllString(perr.Error(), ""),
+				}}
+			}
+			return
+		}
+		err = root.Encode(values)
+	default:
+		err = ErrUnsupportedConfig
+	}
+	return
+}
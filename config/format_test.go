@@ -0,0 +1,117 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFindFile(t *testing.T) {
+	tests := []struct {
+		files []string
+		want  string
+		err   error
+	}{
+		{nil, "docs.yaml", nil},
+		{[]string{"docs.yaml"}, "docs.yaml", nil},
+		{[]string{"docs.yml"}, "docs.yml", nil},
+		{[]string{"docs.toml"}, "docs.toml", nil},
+		{[]string{"docs.json"}, "docs.json", nil},
+		{[]string{"docs.toml", "docs.txt"}, "docs.toml", nil},
+		{[]string{"docs.yaml", "docs.toml"}, "", ErrDuplicateConfig},
+		{[]string{"docs.yml", "docs.json"}, "", ErrDuplicateConfig},
+	}
+	for _, test := range tests {
+		dir := t.TempDir()
+		fsys := make(fstest.MapFS)
+		for _, name := range test.files {
+			if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+			fsys["config/"+name] = &fstest.MapFile{}
+		}
+		path, err := FindFile(dir, "docs")
+		switch {
+		case !errors.Is(err, test.err):
+			t.Errorf("FindFile(%v): error = %v, want %v", test.files, err, test.err)
+		case err == nil && path != filepath.Join(dir, test.want):
+			t.Errorf("FindFile(%v) = %q, want %q", test.files, path, test.want)
+		}
+		path, err = FindFileFS(fsys, "config", "docs")
+		switch {
+		case !errors.Is(err, test.err):
+			t.Errorf("FindFileFS(%v): error = %v, want %v", test.files, err, test.err)
+		case err == nil && path != "config/"+test.want:
+			t.Errorf("FindFileFS(%v) = %q, want %q", test.files, path, test.want)
+		}
+	}
+}
+
+func TestDecodeFormats(t *testing.T) {
+	want := Section{
+		Name:   "Docs",
+		Weight: 10,
+		Templates: Templates{
+			Content:  "page.html",
+			Listings: []string{"list.html", "sublist.html"},
+		},
+		Categories: []*Category{{Name: "Guides"}},
+	}
+	files := map[string]string{
+		"docs.yaml": "name: Docs\nweight: 10\ntemplates:\n    content: page.html\n    listings: [list.html, sublist.html]\ncategories:\n    - name: Guides\n",
+		"docs.yml":  "name: Docs\nweight: 10\ntemplates:\n    content: page.html\n    listings: [list.html, sublist.html]\ncategories:\n    - name: Guides\n",
+		"docs.toml": "name = \"Docs\"\nweight = 10\n\n[templates]\ncontent = \"page.html\"\nlistings = [\"list.html\", \"sublist.html\"]\n\n[[categories]]\nname = \"Guides\"\n",
+		"docs.json": `{"name": "Docs", "weight": 10, "templates": {"content": "page.html", "listings": ["list.html", "sublist.html"]}, "categories": [{"name": "Guides"}]}`,
+	}
+	for name, raw := range files {
+		var got Section
+		if err := Decode(name, strings.NewReader(raw), &got, false); err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: decoded %+v, want %+v", name, got, want)
+		}
+	}
+}
+
+func TestDecodeFormatErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"docs.json", "{\n    \"name\": \"Docs\",\n    \"wieght\": 10\n}", `docs.json:3:5: unknown key "wieght"`},
+		{"docs.json", "{\n    \"weight\": \"heavy\"\n}", "docs.json:2:15: weight: expected an integer, found a string"},
+		{"docs.toml", "weight = 10\nname = \"Docs\nmenu = 1\n", "docs.toml:2: "},
+		{"docs.toml", "name = \"Docs\"\nwieght = 10\n", `docs.toml: unknown key "wieght"`},
+		{"docs.ini", "name = Docs\n", ErrUnsupportedConfig.Error()},
+	}
+	for _, test := range tests {
+		var v Section
+		err := Decode(test.name, strings.NewReader(test.raw), &v, false)
+		if err == nil || !strings.HasPrefix(err.Error(), test.want) {
+			t.Errorf("%s: error = %v, want %q", test.name, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+// MenuEntry is a single link in a navigation Menu, pointing at a Section, a Page, or an external URL
+type MenuEntry struct {
+	Name     string `yaml:"name"`
+	Section  string `yaml:"section"`
+	Page     string `yaml:"page"`
+	URL      string `yaml:"url"`
+	Weight   int    `yaml:"weight"`
+	Icon     string `yaml:"icon"`
+	Children Menu   `yaml:"children"`
+}
+
+// Menu is an ordered list of navigation entries
+type Menu []*MenuEntry
+
+// Menus is a map of named Menus
+type Menus map[string]Menu
+
+// MenuRef registers a Section as an entry in a named Menu
+type MenuRef struct {
+	Name   string `yaml:"name"`
+	Weight int    `yaml:"weight"`
+	Icon   string `yaml:"icon"`
+}
@@ -0,0 +1,65 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Robots configures the robots.txt generated for the Site
+type Robots struct {
+	Rules []RobotsRule `yaml:"rules"`
+}
+
+// RobotsRule is a group of directives for one or more crawlers
+type RobotsRule struct {
+	UserAgents []string `yaml:"user-agents"`
+	Allow      []string `yaml:"allow"`
+	Disallow   []string `yaml:"disallow"`
+}
+
+// Text generates the contents of robots.txt, allowing every crawler if there are no rules
+func (r Robots) Text(sitemap string) string {
+	var b strings.Builder
+	rules := r.Rules
+	if len(rules) == 0 {
+		rules = []RobotsRule{{Disallow: []string{""}}}
+	}
+	for i, rule := range rules {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		agents := rule.UserAgents
+		if len(agents) == 0 {
+			agents = []string{"*"}
+		}
+		for _, agent := range agents {
+			fmt.Fprintf(&b, "User-agent: %s\n", agent)
+		}
+		for _, path := range rule.Allow {
+			fmt.Fprintf(&b, "Allow: %s\n", path)
+		}
+		for _, path := range rule.Disallow {
+			fmt.Fprintf(&b, "Disallow: %s\n", path)
+		}
+	}
+	if sitemap != "" {
+		fmt.Fprintf(&b, "\nSitemap: %s\n", sitemap)
+	}
+	return b.String()
+}
@@ -0,0 +1,44 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"testing"
+)
+
+func TestRobotsText(t *testing.T) {
+	rules := Robots{
+		Rules: []RobotsRule{
+			{UserAgents: []string{"a", "b"}, Allow: []string{"/public/"}, Disallow: []string{"/"}},
+			{Disallow: []string{"/private/"}},
+		},
+	}
+	tests := []struct {
+		robots  Robots
+		sitemap string
+		want    string
+	}{
+		{Robots{}, "", "User-agent: *\nDisallow: \n"},
+		{Robots{}, "https://example.com/sitemap.xml", "User-agent: *\nDisallow: \n\nSitemap: https://example.com/sitemap.xml\n"},
+		{rules, "", "User-agent: a\nUser-agent: b\nAllow: /public/\nDisallow: /\n\nUser-agent: *\nDisallow: /private/\n"},
+	}
+	for _, test := range tests {
+		if got := test.robots.Text(test.sitemap); got != test.want {
+			t.Errorf("Text(%q) = %q, want %q", test.sitemap, got, test.want)
+		}
+	}
+}
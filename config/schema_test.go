@@ -0,0 +1,137 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"encoding/json"
+	"github.com/DataDrake/static-cling/schema"
+	"gopkg.in/yaml.v3"
+	"strings"
+	"testing"
+)
+
+// checkProperties compares the JSON encoding of some of the properties of a Schema
+func checkProperties(t *testing.T, s *schema.Schema, want map[string]string) {
+	for name, expected := range want {
+		prop, ok := s.Properties[name]
+		if !ok {
+			t.Errorf("%s: missing property %q", s.Title, name)
+			continue
+		}
+		raw, err := json.Marshal(prop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(raw); got != expected {
+			t.Errorf("%s: %s = %s, want %s", s.Title, name, got, expected)
+		}
+	}
+}
+
+func TestSiteSchema(t *testing.T) {
+	s := schema.For(Site{})
+	if s.Title != "Site" || s.AdditionalProperties != false {
+		t.Errorf("unexpected root: %s %v", s.Title, s.AdditionalProperties)
+	}
+	checkProperties(t, s, map[string]string{
+		"name":    `{"type":"string"}`,
+		"url":     `{"type":"string"}`,
+		"deploy":  `{"type":"string"}`,
+		"404":     `{"type":"string"}`,
+		"themes":  `{"type":"array","items":{"type":"string"}}`,
+		"authors": `{"type":"object","additionalProperties":{"$ref":"#/$defs/Author"}}`,
+		"menus":   `{"type":"object","additionalProperties":{"type":"array","items":{"$ref":"#/$defs/MenuEntry"}}}`,
+		"reading": `{"$ref":"#/$defs/Reading"}`,
+		"robots":  `{"$ref":"#/$defs/Robots"}`,
+		"vars":    `{"$ref":"#/$defs/Variables"}`,
+	})
+	// fields which are not read from the file
+	for _, name := range []string{"sections", "data", "dir", "env"} {
+		if _, ok := s.Properties[name]; ok {
+			t.Errorf("unexpected property %q", name)
+		}
+	}
+	checkProperties(t, s.Defs["Reading"], map[string]string{
+		"wpm": `{"type":"integer"}`,
+		"cjk": `{"type":"boolean"}`,
+	})
+}
+
+func TestSectionSchema(t *testing.T) {
+	s := schema.For(&Section{})
+	if s.Title != "Section" || s.AdditionalProperties != false {
+		t.Errorf("unexpected root: %s %v", s.Title, s.AdditionalProperties)
+	}
+	checkProperties(t, s, map[string]string{
+		"name":       `{"type":"string"}`,
+		"weight":     `{"type":"integer"}`,
+		"templates":  `{"$ref":"#/$defs/Templates"}`,
+		"categories": `{"type":"array","items":{"$ref":"#/$defs/Category"}}`,
+		"menu":       `{"$ref":"#/$defs/MenuRef"}`,
+		"vars":       `{"$ref":"#/$defs/Variables"}`,
+	})
+	for _, name := range []string{"key", "path", "parent"} {
+		if _, ok := s.Properties[name]; ok {
+			t.Errorf("unexpected property %q", name)
+		}
+	}
+	checkProperties(t, s.Defs["Templates"], map[string]string{
+		"content":  `{"type":"string"}`,
+		"category": `{"type":"string"}`,
+		"listings": `{"type":"array","items":{"type":"string"}}`,
+	})
+	raw := "name: Docs\ntemplates:\n    listings: page.html\ncategories:\n    - name: Guides\n      weight: 1\n"
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(raw), &node); err != nil {
+		t.Fatal(err)
+	}
+	want := []schema.Error{
+		{Line: 3, Column: 15, Message: "templates.listings: expected a list, found a string"},
+		{Line: 6, Column: 7, Message: `categories[0]: unknown key "weight"`},
+	}
+	errs := s.Validate(&node, true)
+	if len(errs) != len(want) {
+		t.Fatalf("found %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, err := range errs {
+		if err != want[i] {
+			t.Errorf("error %d = %+v, want %+v", i, err, want[i])
+		}
+	}
+}
+
+func TestDecodeSchemaErrors(t *testing.T) {
+	var v Site
+	raw := "name: Test\nreading:\n    wpm: fast\n    cjk: 1\n"
+	err := Decode("_site.yaml", strings.NewReader(raw), &v, false)
+	errs, ok := err.(DecodeErrors)
+	if !ok {
+		t.Fatalf("error = %v, want DecodeErrors", err)
+	}
+	want := []DecodeError{
+		{Path: "_site.yaml", Line: 3, Column: 10, Message: "reading.wpm: expected an integer, found a string"},
+		{Path: "_site.yaml", Line: 4, Column: 10, Message: "reading.cjk: expected a boolean, found an integer"},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("found %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, e := range errs {
+		if *e != want[i] {
+			t.Errorf("error %d = %+v, want %+v", i, *e, want[i])
+		}
+	}
+}
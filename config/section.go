@@ -17,10 +17,15 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"github.com/DataDrake/static-cling/file"
 	log "github.com/DataDrake/waterlog"
 	"gopkg.in/yaml.v3"
-	"os"
+	"io/fs"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
@@ -28,8 +33,13 @@ import (
 // Section for rendering content
 type Section struct {
 	Name       string      `yaml:"name"`
+	Key        string      `yaml:"-"`
+	Path       string      `yaml:"-"`
+	Parent     *Section    `yaml:"-"`
+	Weight     int         `yaml:"weight"`
 	Templates  Templates   `yaml:"templates"`
 	Categories []*Category `yaml:"categories"`
+	Menu       *MenuRef    `yaml:"menu"`
 	Vars       Variables   `yaml:"vars"`
 }
 
@@ -40,59 +50,193 @@ func NewSection() *Section {
 	}
 }
 
-// Sections is a map of Section configurations
+// Sections is a map of Section configurations, keyed by their path within the content tree (eg. "docs/api")
 type Sections map[string]*Section
 
-func loadSections(dir string) (confs Sections, modified time.Time, err error) {
+// ErrNoParentSection indicates that a nested Section has no configuration for the Section containing it
+var ErrNoParentSection = errors.New("nested section has no parent section")
+
+// List provides the Sections ordered by weight and then by name
+func (ss Sections) List() (list []*Section) {
+	for _, section := range ss {
+		list = append(list, section)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Weight != list[j].Weight {
+			return list[i].Weight < list[j].Weight
+		}
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].Key < list[j].Key
+	})
+	return
+}
+
+// loadSections reads the configuration of every Section, skipping over any which cannot be loaded
+//
+// Section configurations from themes act as defaults, and a theme may provide a Section of its own.
+// Every problem found is returned as Errors, alongside the Sections which did load.
+func loadSections(dir string, themes []file.Source, o *overlay, opts Options) (confs Sections, modified time.Time, err error) {
+	confs = make(Sections)
+	var errs Errors
+	defaults, themePaths, modified := loadThemeSections(themes, opts, &errs)
+	paths := make(map[string]string)
+	if err = findSections(nil, dir, "", paths, &errs); err != nil {
+		return
+	}
+	keys := make([]string, 0, len(paths))
+	for key := range paths {
+		keys = append(keys, key)
+	}
+	for key := range themePaths {
+		if _, ok := paths[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		path, ok := paths[key]
+		if !ok {
+			path = themePaths[key]
+		}
+		conf, latest, err := loadSection(path, ok, defaults[key], o.section(key), opts)
+		if err != nil {
+			errs.add(err)
+			continue
+		}
+		if latest.After(modified) {
+			modified = latest
+		}
+		conf.Key = key
+		confs[key] = conf
+	}
+	errs.add(confs.link())
+	errs.add(o.checkSections(confs))
+	err = errs.err()
+	return
+}
+
+// findSections recursively finds the configuration file for each Section, keyed by its path relative to the configuration directory
+//
+// Subdirectories hold the configuration for nested Sections, except for those starting with "_".
+//
+// Configuration files for the same Section in more than one format are recorded in errs and skipped.
+//
+// The directory is read from fsys, or from disk if it is nil.
+func findSections(fsys fs.FS, dir, prefix string, paths map[string]string, errs *Errors) error {
 	log.Debugf("Loading configuration files from %q\n", dir)
-	entries, err := os.ReadDir(dir)
+	entries, err := file.ReadDir(fsys, dir)
 	if err != nil {
-		return
+		return err
 	}
-	confs = make(Sections)
-	var conf *Section
-	var latest time.Time
+	found := make(map[string][]string)
 	for _, entry := range entries {
 		name := entry.Name()
-		path := filepath.Join(dir, name)
-		if name == SiteFile {
+		path := file.Join(fsys, dir, name)
+		if entry.IsDir() {
+			if strings.HasPrefix(name, "_") {
+				log.Debugf("Skipping directory %q\n", path)
+				continue
+			}
+			if err = findSections(fsys, path, prefix+name+"/", paths, errs); err != nil {
+				return err
+			}
+			continue
+		}
+		ext := filepath.Ext(name)
+		if !IsConfig(ext) {
+			log.Warnf("Skipping unsupported configuration file %q\n", path)
+			continue
+		}
+		key := prefix + strings.TrimSuffix(name, ext)
+		if key == SiteName {
 			log.Debugf("Skipping site configuration file %q\n", path)
 			continue
 		}
-		if entry.IsDir() {
-			log.Debugf("Skipping directory %q\n", path)
+		found[key] = append(found[key], path)
+	}
+	for key, files := range found {
+		if len(files) > 1 {
+			errs.add(duplicateConfig(files))
 			continue
 		}
-		if conf, latest, err = loadSection(path); err != nil {
+		paths[key] = files[0]
+	}
+	return nil
+}
+
+// loadSection decodes the configuration of a Section, on top of the defaults from any themes
+//
+// When read is false, the Section only exists in a theme and path is the theme's configuration file.
+func loadSection(path string, read bool, defaults, over *yaml.Node, opts Options) (conf *Section, modified time.Time, err error) {
+	log.Debugf("Loading section configuration %q\n", path)
+	var root *yaml.Node
+	if read {
+		if root, modified, err = readFile(path); err != nil {
 			return
 		}
-		if latest.After(modified) {
-			modified = latest
+		if err = interpolate(path, root); err != nil {
+			return
 		}
-		name = strings.TrimSuffix(name, filepath.Ext(name))
-		confs[name] = conf
 	}
+	conf = NewSection()
+	conf.Path = path
+	err = decodeNode(path, mergeNodes(mergeNodes(defaults, root), over), conf, opts.Lenient)
 	return
 }
 
-func loadSection(path string) (conf *Section, modified time.Time, err error) {
-	log.Debugf("Loading section configuration %q\n", path)
-	f, err := os.Open(path)
-	if err != nil {
-		return
-	}
-	defer f.Close()
-	info, err := f.Stat()
-	if err != nil {
-		return
+// Children provides the Sections nested directly inside of a Section, or the top-level Sections for nil
+func (ss Sections) Children(parent *Section) (children []*Section) {
+	for _, section := range ss.List() {
+		if section.Parent == parent {
+			children = append(children, section)
+		}
 	}
-	modified = info.ModTime()
-	conf = NewSection()
-	dec := yaml.NewDecoder(f)
-	err = dec.Decode(conf)
 	return
 }
 
+// link sets the Parent of every nested Section, inheriting any templates it does not set itself
+//
+// Each template is inherited unchanged: a nested Section without listings of its own uses the full
+// list of its parent, so its index is rendered with the first listing like that of any other Section.
+// Nested Sections without a parent are removed and reported.
+func (ss Sections) link() error {
+	var errs Errors
+	keys := make([]string, 0, len(ss))
+	for key := range ss {
+		keys = append(keys, key)
+	}
+	// parents must be linked before their children
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.Count(keys[i], "/") < strings.Count(keys[j], "/")
+	})
+	for _, key := range keys {
+		if !strings.Contains(key, "/") {
+			continue
+		}
+		section := ss[key]
+		parent, ok := ss[path.Dir(key)]
+		if !ok {
+			errs.add(fmt.Errorf("%w: %q needs %q", ErrNoParentSection, key, path.Dir(key)))
+			delete(ss, key)
+			continue
+		}
+		section.Parent = parent
+		tmpls := &section.Templates
+		if tmpls.Content == "" {
+			tmpls.Content = parent.Templates.Content
+		}
+		if tmpls.Category == "" {
+			tmpls.Category = parent.Templates.Category
+		}
+		if tmpls.Listings == nil {
+			tmpls.Listings = parent.Templates.Listings
+		}
+	}
+	return errs.err()
+}
+
 // HasCategory determines if a Category exists in this Section
 func (s *Section) HasCategory(name string) bool {
 	for _, category := range s.Categories {
@@ -102,3 +246,13 @@ func (s *Section) HasCategory(name string) bool {
 	}
 	return false
 }
+
+// Category retrieves a Category of this Section by name, ignoring case
+func (s *Section) Category(name string) *Category {
+	for _, category := range s.Categories {
+		if strings.EqualFold(category.Name, name) {
+			return category
+		}
+	}
+	return nil
+}
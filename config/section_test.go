@@ -0,0 +1,174 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"errors"
+	"github.com/DataDrake/static-cling/file"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadSectionsThemes(t *testing.T) {
+	dir := t.TempDir()
+	project := "name: Blog\ntemplates:\n    content: post\n"
+	if err := os.WriteFile(filepath.Join(dir, "blog.yaml"), []byte(project), 0644); err != nil {
+		t.Fatal(err)
+	}
+	first := fstest.MapFS{
+		"config/blog.yaml": {Data: []byte("weight: 2\ntemplates:\n    content: article\n    category: topic\n")},
+	}
+	second := fstest.MapFS{
+		"config/blog.yaml": {Data: []byte("weight: 3\ntemplates:\n    listings:\n        - list\n")},
+		"config/docs.yaml": {Data: []byte("name: Docs\n")},
+	}
+	themes := []file.Source{file.NewSource(first, "first"), file.NewSource(second, "second")}
+	confs, _, err := loadSections(dir, themes, nil, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	blog := confs["blog"]
+	switch {
+	case blog == nil:
+		t.Fatal("missing section \"blog\"")
+	case blog.Name != "Blog":
+		t.Errorf("name = %q, want %q", blog.Name, "Blog")
+	case blog.Weight != 2:
+		t.Errorf("weight = %d, want 2", blog.Weight)
+	case blog.Templates.Content != "post":
+		t.Errorf("content template = %q, want %q", blog.Templates.Content, "post")
+	case blog.Templates.Category != "topic":
+		t.Errorf("category template = %q, want %q", blog.Templates.Category, "topic")
+	case len(blog.Templates.Listings) != 1 || blog.Templates.Listings[0] != "list":
+		t.Errorf("listings = %v, want [list]", blog.Templates.Listings)
+	case blog.Path != filepath.Join(dir, "blog.yaml"):
+		t.Errorf("path = %q, want the project's configuration", blog.Path)
+	}
+	docs := confs["docs"]
+	switch {
+	case docs == nil:
+		t.Fatal("missing section \"docs\" from the theme")
+	case docs.Name != "Docs":
+		t.Errorf("name = %q, want %q", docs.Name, "Docs")
+	case docs.Path != "config/docs.yaml":
+		t.Errorf("path = %q, want the theme's configuration", docs.Path)
+	}
+}
+
+func TestLoadSectionsUnknownKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "blog.yaml")
+	raw := "name: Blog\ntemplates:\n    content: post\n    listing:\n        - index\n"
+	if err := os.WriteFile(path, []byte(raw), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err := loadSections(dir, nil, nil, Options{})
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("error = %v, want a single problem", err)
+	}
+	de, ok := errs[0].(*DecodeError)
+	switch {
+	case !ok:
+		t.Errorf("error = %#v, want a DecodeError", errs[0])
+	case de.Path != path || de.Line != 4 || de.Column != 5:
+		t.Errorf("location = %q, want %s:4:5", de.Location(), path)
+	case !strings.Contains(de.Message, `"listing"`):
+		t.Errorf("message = %q, want it to name \"listing\"", de.Message)
+	}
+	confs, _, err := loadSections(dir, nil, nil, Options{Lenient: true})
+	if err != nil {
+		t.Fatalf("lenient: unexpected error: %s", err)
+	}
+	if blog := confs["blog"]; blog == nil || blog.Templates.Content != "post" || blog.Templates.Listings != nil {
+		t.Errorf("lenient: blog = %#v, want the typo ignored", blog)
+	}
+}
+
+func TestSectionsLink(t *testing.T) {
+	docs := &Section{Templates: Templates{Content: "doc", Category: "topic", Listings: []string{"list", "sublist"}}}
+	api := &Section{}
+	v1 := &Section{Templates: Templates{Content: "reference"}}
+	guide := &Section{Templates: Templates{Listings: []string{"guides"}}}
+	ss := Sections{
+		"docs":        docs,
+		"docs/api":    api,
+		"docs/api/v1": v1,
+		"docs/guide":  guide,
+		"blog/drafts": &Section{},
+	}
+	errs, ok := ss.link().(Errors)
+	if !ok || len(errs) != 1 || !errors.Is(errs[0], ErrNoParentSection) {
+		t.Errorf("error = %v, want %v", errs, ErrNoParentSection)
+	}
+	if _, ok := ss["blog/drafts"]; ok {
+		t.Error("orphaned section \"blog/drafts\" was kept")
+	}
+	tests := []struct {
+		key      string
+		parent   *Section
+		content  string
+		category string
+		listings string
+	}{
+		{"docs", nil, "doc", "topic", "list sublist"},
+		{"docs/api", docs, "doc", "topic", "list sublist"},
+		{"docs/api/v1", api, "reference", "topic", "list sublist"},
+		{"docs/guide", docs, "doc", "topic", "guides"},
+	}
+	for _, test := range tests {
+		section := ss[test.key]
+		tmpls := section.Templates
+		switch {
+		case section.Parent != test.parent:
+			t.Errorf("%s: wrong parent %v", test.key, section.Parent)
+		case tmpls.Content != test.content || tmpls.Category != test.category:
+			t.Errorf("%s: content = %q, category = %q, want %q and %q", test.key, tmpls.Content, tmpls.Category, test.content, test.category)
+		case strings.Join(tmpls.Listings, " ") != test.listings:
+			t.Errorf("%s: listings = %v, want %q", test.key, tmpls.Listings, test.listings)
+		}
+	}
+	children := ss.Children(docs)
+	if len(children) != 2 || children[0] != api || children[1] != guide {
+		t.Errorf("children of docs = %v, want api and guide", children)
+	}
+}
+
+func TestSectionsList(t *testing.T) {
+	ss := Sections{
+		"news":    {Key: "news", Name: "News", Weight: 20},
+		"docs":    {Key: "docs", Name: "Docs", Weight: 10},
+		"blog":    {Key: "blog", Name: "Blog", Weight: 20},
+		"about":   {Key: "about", Name: "About"},
+		"guide":   {Key: "guide", Name: "Docs", Weight: 10},
+		"archive": {Key: "archive", Name: "Archive", Weight: -5},
+	}
+	want := []string{"archive", "about", "docs", "guide", "blog", "news"}
+	// map iteration is random, so check several times
+	for i := 0; i < 10; i++ {
+		var keys []string
+		for _, section := range ss.List() {
+			keys = append(keys, section.Key)
+		}
+		if got := strings.Join(keys, " "); got != strings.Join(want, " ") {
+			t.Fatalf("List() = %s, want %s", got, strings.Join(want, " "))
+		}
+	}
+}
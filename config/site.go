@@ -18,15 +18,14 @@ package config
 
 import (
 	log "github.com/DataDrake/waterlog"
-	"gopkg.in/yaml.v3"
 	"os"
 	"path/filepath"
 	"reflect"
 	"time"
 )
 
-// SiteFile is the relative path of the Site configuration
-var SiteFile = "_site.yaml"
+// SiteName is the name of the Site configuration, without an extension
+const SiteName = "_site"
 
 // Dir is the relative directory for configuration files
 const Dir = "config"
@@ -39,58 +38,119 @@ func Path(src string) string {
 // Site is the full configuration for the site
 type Site struct {
 	Name       string    `yaml:"name"`
+	URL        string    `yaml:"url"`
 	Deployment string    `yaml:"deploy"`
+	Themes     []string  `yaml:"themes"`
 	Vars       Variables `yaml:"vars"`
+	Menus      Menus     `yaml:"menus"`
+	Authors    Authors   `yaml:"authors"`
+	Reading    Reading   `yaml:"reading"`
+	Robots     Robots    `yaml:"robots"`
+	NotFound   string    `yaml:"404"`
 	Sections   Sections  `yaml:"-"`
+	Data       Data      `yaml:"-"`
 	Dir        string    `yaml:"-"`
+	Env        string    `yaml:"-"`
+	options    Options
 	modified   time.Time
 }
 
+// Options control how the configuration is loaded
+type Options struct {
+	// Env is the name of the environment being built, selecting an overlay from EnvDir if set
+	Env string
+	// Lenient allows unknown keys in configuration and page metadata, instead of rejecting them
+	Lenient bool
+}
+
 // Load parses all of the config directories
-func Load(dir string) (conf Site, err error) {
-	if conf, err = loadSite(dir); err != nil {
-		log.Errorf("Failed to load site config, reason: %q\n", err)
-		return
+//
+// Loading carries on past broken files, so that every problem can be reported at once as Errors,
+// alongside whatever configuration could still be loaded.
+func Load(dir string, opts Options) (conf Site, err error) {
+	var errs Errors
+	o, err := loadOverlay(dir, opts)
+	if err != nil {
+		log.Errorf("Failed to load environment config, reason:\n%s\n", err)
+		errs.add(err)
+	}
+	if conf, err = loadSite(dir, o, opts); err != nil {
+		log.Errorf("Failed to load site config, reason:\n%s\n", err)
+		errs.add(err)
+	}
+	themes, err := conf.ThemeSources()
+	if err != nil {
+		// missing themes are reported while loading the Site
+		themes = nil
 	}
 	var modified time.Time
-	if conf.Sections, modified, err = loadSections(dir); err != nil {
-		log.Errorf("Failed to load section configs, reason: %q\n", err)
+	if conf.Sections, modified, err = loadSections(dir, themes, o, opts); err != nil {
+		log.Errorf("Failed to load section configs, reason:\n%s\n", err)
+		errs.add(err)
 	}
 	if modified.After(conf.modified) {
 		conf.modified = modified
 	}
+	if conf.Data, modified, err = loadData(DataPath(dir)); err != nil {
+		log.Errorf("Failed to load data files, reason: %q\n", err)
+		errs.add(err)
+	}
+	if modified.After(conf.modified) {
+		conf.modified = modified
+	}
+	err = errs.err()
 	return
 }
 
 // NewSite creates an empty Site configuration
 func NewSite() Site {
 	return Site{
-		Vars: NewVariables(),
+		Vars:     NewVariables(),
+		Reading:  NewReading(),
+		NotFound: "404",
 	}
 }
 
-func loadSite(dir string) (conf Site, err error) {
-	file := filepath.Join(dir, SiteFile)
+func loadSite(dir string, o *overlay, opts Options) (conf Site, err error) {
+	conf = NewSite()
+	conf.Dir = dir
+	conf.options = opts
+	conf.Env = opts.Env
+	file, err := FindFile(dir, SiteName)
+	if err != nil {
+		return
+	}
 	log.Debugf("Loading site configuration file: %q\n", file)
-	f, err := os.Open(file)
+	root, modified, err := readFile(file)
 	if err != nil {
 		if !os.IsNotExist(err) {
 			return
 		}
 		log.Warnf("Site configuration %q not found\n", file)
 		err = nil
+	}
+	conf.modified = modified
+	if err = interpolate(file, root); err != nil {
 		return
 	}
-	defer f.Close()
-	info, err := f.Stat()
+	if o != nil {
+		root = mergeNodes(root, o.site)
+		if o.modified.After(conf.modified) {
+			conf.modified = o.modified
+		}
+	}
+	defaults, modified, err := loadThemes(dir, root, opts)
 	if err != nil {
 		return
 	}
-	conf = NewSite()
-	conf.modified = info.ModTime()
-	conf.Dir = dir
-	dec := yaml.NewDecoder(f)
-	err = dec.Decode(&conf)
+	if modified.After(conf.modified) {
+		conf.modified = modified
+	}
+	root = mergeNodes(defaults, root)
+	if err = decodeNode(file, root, &conf, opts.Lenient); err != nil {
+		return
+	}
+	conf.Authors.setKeys()
 	return
 }
 
@@ -106,7 +166,7 @@ func (s *Site) IsNewer(other time.Time) bool {
 
 // Update rereads the configuration from disk and checks if it has changed, updating if it has
 func (s *Site) Update() (changed bool, err error) {
-	next, err := Load(s.Dir)
+	next, err := Load(s.Dir, s.options)
 	if err != nil {
 		return
 	}
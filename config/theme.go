@@ -0,0 +1,160 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DataDrake/static-cling/file"
+	"github.com/DataDrake/static-cling/themes"
+	log "github.com/DataDrake/waterlog"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ThemesDir is the relative directory of themes which are referred to by name
+const ThemesDir = "themes"
+
+// ErrMissingTheme indicates that a theme used by the Site does not exist
+var ErrMissingTheme = errors.New("theme not found")
+
+// ThemeSource finds a theme, relative to the source of a project
+//
+// A bare name (eg. "plain") refers to a directory or archive in ThemesDir, falling back to a theme
+// which is built in. Anything else is the path of a directory or archive.
+func ThemeSource(src, theme string) (source file.Source, err error) {
+	var candidates []string
+	switch {
+	case filepath.IsAbs(theme):
+		candidates = []string{theme}
+	case strings.ContainsAny(theme, `/\`) || theme == "." || theme == ".." || file.IsArchive(theme):
+		candidates = []string{filepath.Join(src, theme)}
+	default:
+		dir := filepath.Join(src, ThemesDir, theme)
+		candidates = []string{dir, dir + ".zip", dir + ".tar", dir + ".tar.gz", dir + ".tgz"}
+	}
+	for _, path := range candidates {
+		if _, err = os.Stat(path); err == nil {
+			return file.OpenSource(path)
+		}
+	}
+	if builtin, ok := themes.Get(theme); ok {
+		return builtin, nil
+	}
+	err = fmt.Errorf("%w: %s", ErrMissingTheme, candidates[0])
+	return
+}
+
+// ThemeSources finds each theme used by this Site, in order of precedence
+func (s *Site) ThemeSources() (sources []file.Source, err error) {
+	src := filepath.Dir(filepath.Clean(s.Dir))
+	for _, theme := range s.Themes {
+		var source file.Source
+		if source, err = ThemeSource(src, theme); err != nil {
+			return
+		}
+		sources = append(sources, source)
+	}
+	return
+}
+
+// loadThemeSections reads the default Section configurations of each theme, merged so that earlier themes take precedence
+//
+// paths holds the configuration file with the highest precedence for each Section.
+func loadThemeSections(themes []file.Source, opts Options, errs *Errors) (defaults map[string]*yaml.Node, paths map[string]string, modified time.Time) {
+	defaults = make(map[string]*yaml.Node)
+	paths = make(map[string]string)
+	for i := len(themes) - 1; i >= 0; i-- {
+		source := themes[i]
+		found := make(map[string]string)
+		if err := findSections(source.FS, file.Join(source.FS, source.Path, Dir), "", found, errs); err != nil {
+			if !os.IsNotExist(err) {
+				errs.add(err)
+			}
+			continue
+		}
+		for key, path := range found {
+			theme, latest, err := readFileFS(source.FS, path)
+			if err != nil {
+				errs.add(err)
+				continue
+			}
+			if latest.After(modified) {
+				modified = latest
+			}
+			if err = interpolate(path, theme); err != nil {
+				errs.add(err)
+				continue
+			}
+			if err = validate(path, theme, NewSection(), opts.Lenient); err != nil {
+				errs.add(err)
+				continue
+			}
+			defaults[key] = mergeNodes(defaults[key], theme)
+			paths[key] = path
+		}
+	}
+	return
+}
+
+// loadThemes reads the default Site configuration of each theme, merged so that earlier themes take precedence
+func loadThemes(dir string, root *yaml.Node, opts Options) (defaults *yaml.Node, modified time.Time, err error) {
+	if root == nil || root.Kind != yaml.MappingNode {
+		return
+	}
+	value := mappingValue(root, "themes")
+	var names []string
+	if value == nil || value.Decode(&names) != nil {
+		// invalid themes are reported when the Site is decoded
+		return
+	}
+	src := filepath.Dir(filepath.Clean(dir))
+	for i := len(names) - 1; i >= 0; i-- {
+		var source file.Source
+		if source, err = ThemeSource(src, names[i]); err != nil {
+			return
+		}
+		var path string
+		if path, err = FindFileFS(source.FS, file.Join(source.FS, source.Path, Dir), SiteName); err != nil {
+			return
+		}
+		log.Debugf("Loading theme configuration file: %q\n", path)
+		var theme *yaml.Node
+		var latest time.Time
+		if theme, latest, err = readFileFS(source.FS, path); err != nil {
+			if os.IsNotExist(err) {
+				err = nil
+				continue
+			}
+			return
+		}
+		if latest.After(modified) {
+			modified = latest
+		}
+		if err = interpolate(path, theme); err != nil {
+			return
+		}
+		if err = validate(path, theme, &Site{}, opts.Lenient); err != nil {
+			return
+		}
+		defaults = mergeNodes(defaults, theme)
+	}
+	return
+}
@@ -17,17 +17,84 @@
 package config
 
 // Variables for rendering templates
+//
+// Any key may hold arbitrary YAML. The "map", "maps" and "lists" keys are still
+// supported for older configurations, with their entries flattened into Params.
 type Variables struct {
-	Map   map[string]string            `yaml:"map"`
-	Maps  map[string]map[string]string `yaml:"maps"`
-	Lists map[string][]string          `yaml:"lists"`
+	Map    map[string]string            `yaml:"map"`
+	Maps   map[string]map[string]string `yaml:"maps"`
+	Lists  map[string][]string          `yaml:"lists"`
+	Values map[string]interface{}       `yaml:",inline"`
 }
 
 // NewVariables creates an empty Variables structure
 func NewVariables() Variables {
 	return Variables{
-		Map:   make(map[string]string),
-		Maps:  make(map[string]map[string]string),
-		Lists: make(map[string][]string),
+		Map:    make(map[string]string),
+		Maps:   make(map[string]map[string]string),
+		Lists:  make(map[string][]string),
+		Values: make(map[string]interface{}),
+	}
+}
+
+// Params flattens these Variables into a single set of Params
+func (v Variables) Params() Params {
+	params := make(Params)
+	for key, value := range v.Map {
+		params[key] = value
+	}
+	for key, value := range v.Maps {
+		m := make(map[string]interface{})
+		for k, v := range value {
+			m[k] = v
+		}
+		params[key] = m
+	}
+	for key, value := range v.Lists {
+		l := make([]interface{}, len(value))
+		for i, v := range value {
+			l[i] = v
+		}
+		params[key] = l
+	}
+	params.merge(v.Values)
+	return params
+}
+
+// Params are the merged Variables available to a template as ".Params"
+type Params map[string]interface{}
+
+// MergeParams combines several Variables into a single set of Params
+//
+// Variables are listed in increasing order of precedence. When rendering, this is:
+//
+//  1. Site
+//  2. Section
+//  3. Category
+//  4. Page
+//
+// Nested maps are merged key by key, while any other value replaces the value
+// from a lower precedence.
+func MergeParams(vars ...Variables) Params {
+	params := make(Params)
+	for _, v := range vars {
+		params.merge(v.Params())
+	}
+	return params
+}
+
+// merge recursively copies values into these Params, replacing existing values
+func (p Params) merge(values map[string]interface{}) {
+	for key, value := range values {
+		next, ok := value.(map[string]interface{})
+		if !ok {
+			p[key] = value
+			continue
+		}
+		prev, _ := p[key].(map[string]interface{})
+		merged := make(Params)
+		merged.merge(prev)
+		merged.merge(next)
+		p[key] = map[string]interface{}(merged)
 	}
 }
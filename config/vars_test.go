@@ -0,0 +1,103 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVariablesParams(t *testing.T) {
+	v := Variables{
+		Map:   map[string]string{"title": "legacy", "color": "red"},
+		Maps:  map[string]map[string]string{"social": {"github": "me"}},
+		Lists: map[string][]string{"tags": {"a", "b"}},
+		Values: map[string]interface{}{
+			"title":  "typed",
+			"count":  3,
+			"social": map[string]interface{}{"mastodon": "@me"},
+		},
+	}
+	want := Params{
+		"title":  "typed",
+		"color":  "red",
+		"count":  3,
+		"tags":   []interface{}{"a", "b"},
+		"social": map[string]interface{}{"github": "me", "mastodon": "@me"},
+	}
+	if got := v.Params(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Params() = %v, want %v", got, want)
+	}
+}
+
+func TestMergeParams(t *testing.T) {
+	site := Variables{Values: map[string]interface{}{
+		"title": "site",
+		"theme": map[string]interface{}{"color": "red", "font": "serif"},
+		"tags":  []interface{}{"site"},
+		"only":  "site",
+	}}
+	section := Variables{Values: map[string]interface{}{
+		"title": "section",
+		"theme": map[string]interface{}{"color": "blue"},
+	}}
+	category := Variables{Map: map[string]string{"title": "category"}}
+	page := Variables{Values: map[string]interface{}{
+		"title": "page",
+		"theme": map[string]interface{}{"font": "mono"},
+		"tags":  []interface{}{"page"},
+	}}
+	tests := []struct {
+		name string
+		vars []Variables
+		want Params
+	}{
+		{
+			name: "site",
+			vars: []Variables{site},
+			want: Params{"title": "site", "theme": map[string]interface{}{"color": "red", "font": "serif"}, "tags": []interface{}{"site"}, "only": "site"},
+		},
+		{
+			name: "site and section",
+			vars: []Variables{site, section},
+			want: Params{"title": "section", "theme": map[string]interface{}{"color": "blue", "font": "serif"}, "tags": []interface{}{"site"}, "only": "site"},
+		},
+		{
+			name: "site, section and category",
+			vars: []Variables{site, section, category},
+			want: Params{"title": "category", "theme": map[string]interface{}{"color": "blue", "font": "serif"}, "tags": []interface{}{"site"}, "only": "site"},
+		},
+		{
+			name: "site, section, category and page",
+			vars: []Variables{site, section, category, page},
+			want: Params{"title": "page", "theme": map[string]interface{}{"color": "blue", "font": "mono"}, "tags": []interface{}{"page"}, "only": "site"},
+		},
+		{
+			name: "none",
+			want: Params{},
+		},
+	}
+	for _, test := range tests {
+		if got := MergeParams(test.vars...); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: MergeParams() = %v, want %v", test.name, got, test.want)
+		}
+	}
+	// merging must not modify the nested maps of lower precedence Variables
+	if theme := site.Values["theme"].(map[string]interface{}); theme["color"] != "red" || theme["font"] != "serif" {
+		t.Errorf("site variables were modified: %v", theme)
+	}
+}
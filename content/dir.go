@@ -17,14 +17,26 @@
 package content
 
 import (
+	"github.com/DataDrake/static-cling/config"
 	"github.com/DataDrake/static-cling/file"
+	log "github.com/DataDrake/waterlog"
+	"sort"
+	"time"
 )
 
+// IsMeta checks if a File holds Page metadata, in any of the supported configuration formats
+func IsMeta(f *file.File) bool {
+	return config.IsConfig(f.Ext)
+}
+
 // Dir is a directory of the content Tree
 type Dir struct {
 	*file.Dir
-	Subs  map[string]*Dir
-	Pages Pages
+	URL     string
+	Subs    map[string]*Dir
+	Pages   Pages
+	opts    Options
+	updated time.Time
 }
 
 // NewDir creates a new directory for the specified path
@@ -33,18 +45,119 @@ func NewDir(path string) (d *Dir, err error) {
 	if err != nil {
 		return
 	}
-	d = &Dir{
-		Dir: dir,
-	}
+	d = newDir(dir, "/", Options{})
 	return
 }
 
+// newDir wraps an existing file.Dir, recursively, reading its Pages with a set of Options
+func newDir(dir *file.Dir, url string, opts Options) *Dir {
+	d := &Dir{
+		Dir:  dir,
+		URL:  url,
+		Subs: make(map[string]*Dir),
+		opts: opts,
+	}
+	d.updateSubs()
+	return d
+}
+
 // Update rereads the underlying directory and updates the pages as needed
 func (d *Dir) Update(force bool) error {
 	if err := d.Read(); err != nil {
 		return err
 	}
-	// TODO update the Subs map
-	// TODO update the Pages list
+	d.updateSubs()
+	return d.update(force)
+}
+
+// update refreshes the Pages in this directory and its Subs, without rereading from disk
+func (d *Dir) update(force bool) error {
+	if err := d.updatePages(force); err != nil {
+		return err
+	}
+	for _, sub := range d.Subs {
+		if err := sub.update(force); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// updateSubs adds and removes Subs to match the underlying directory
+func (d *Dir) updateSubs() {
+	for name := range d.Subs {
+		if _, ok := d.Dirs[name]; !ok {
+			delete(d.Subs, name)
+		}
+	}
+	for name, dir := range d.Dirs {
+		if sub, ok := d.Subs[name]; ok {
+			sub.updateSubs()
+			continue
+		}
+		d.Subs[name] = newDir(dir, d.URL+name+"/", d.opts)
+	}
+}
+
+// updatePages creates, updates, and removes Pages to match the files in this directory
+func (d *Dir) updatePages(force bool) error {
+	existing := make(map[string]*Page)
+	for _, page := range d.Pages {
+		existing[page.file.Name+page.file.Ext] = page
+	}
+	var pages Pages
+	for name, f := range d.Files {
+		if IsMeta(f) {
+			continue
+		}
+		page, ok := existing[name]
+		if !ok {
+			var err error
+			if page, err = NewPage(f, d.opts); err != nil {
+				if err == ErrUnsupportedContent {
+					log.Warnf("Skipping unsupported content %q\n", f.Path())
+					continue
+				}
+				return err
+			}
+		} else if force || page.IsNewer(d.updated) {
+			if err := page.Update(); err != nil {
+				return err
+			}
+		}
+		page.URL = d.URL + page.file.Name + ".html"
+		if page.file.Name == "index" {
+			page.URL = d.URL
+		}
+		pages = append(pages, page)
+	}
+	sort.Slice(pages, func(i, j int) bool {
+		return pages[i].file.Name < pages[j].file.Name
+	})
+	d.Pages = pages.ByWeight()
+	d.link()
+	d.updated = time.Now()
+	return nil
+}
+
+// link sets the Prev and Next Page of each Page in this directory, ordered by weight and then by name
+func (d *Dir) link() {
+	for i, page := range d.Pages {
+		page.Prev, page.Next = nil, nil
+		if i > 0 {
+			page.Prev = d.Pages[i-1]
+		}
+		if i < len(d.Pages)-1 {
+			page.Next = d.Pages[i+1]
+		}
+	}
+}
+
+// All gets every Page in this directory and its Subs, recursively
+func (d *Dir) All() (pages Pages) {
+	pages = append(pages, d.Pages...)
+	for _, sub := range d.Subs {
+		pages = append(pages, sub.All()...)
+	}
+	return
+}
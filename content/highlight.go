@@ -0,0 +1,75 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package content
+
+import (
+	"fmt"
+	"github.com/alecthomas/chroma"
+	chtml "github.com/alecthomas/chroma/formatters/html"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/alecthomas/chroma/styles"
+	"html"
+	"html/template"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// codePattern matches a block of code with a language class, as generated by Markdown
+var codePattern = regexp.MustCompile(`(?s)<pre[^>]*>\s*<code[^>]*\sclass="language-([^"\s]+)[^"]*"[^>]*>(.*?)</code>\s*</pre>`)
+
+// highlighter renders highlighted code with CSS classes, to match the output of HighlightCSS
+var highlighter = chtml.New(chtml.WithClasses(true))
+
+// highlightCode replaces each block of code with a known language by its highlighted equivalent
+func highlightCode(raw template.HTML) (out template.HTML, err error) {
+	result := codePattern.ReplaceAllStringFunc(string(raw), func(block string) string {
+		if err != nil {
+			return block
+		}
+		match := codePattern.FindStringSubmatch(block)
+		lexer := lexers.Get(match[1])
+		if lexer == nil {
+			return block
+		}
+		var iter chroma.Iterator
+		if iter, err = chroma.Coalesce(lexer).Tokenise(nil, html.UnescapeString(match[2])); err != nil {
+			return block
+		}
+		var b strings.Builder
+		if err = highlighter.Format(&b, styles.Fallback, iter); err != nil {
+			return block
+		}
+		return b.String()
+	})
+	out = template.HTML(result)
+	return
+}
+
+// HighlightCSS writes the stylesheet for highlighted code, using the named chroma style
+func HighlightCSS(w io.Writer, name string) error {
+	style, ok := styles.Registry[name]
+	if !ok {
+		return fmt.Errorf("unknown highlighting style %q", name)
+	}
+	return highlighter.WriteCSS(w, style)
+}
+
+// HighlightStyles lists the names of the supported highlighting styles
+func HighlightStyles() []string {
+	return styles.Names()
+}
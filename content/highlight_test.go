@@ -0,0 +1,71 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package content
+
+import (
+	"bytes"
+	"github.com/russross/blackfriday/v2"
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func TestHighlightCode(t *testing.T) {
+	markdown := string(blackfriday.Run([]byte("Intro\n\n```go\nfunc main() {}\n```\n")))
+	tests := []struct {
+		name        string
+		raw         string
+		highlighted bool
+		contains    string
+	}{
+		{"fenced markdown", markdown, true, `<span class="kd">func</span>`},
+		{"html content", `<pre><code class="language-python">print(&quot;hi&quot;)</code></pre>`, true, `&#34;hi&#34;`},
+		{"extra classes", `<pre class="x"><code class="language-sh extra">ls -la</code></pre>`, true, "ls"},
+		{"unknown language", `<pre><code class="language-nosuchlang">x := 1</code></pre>`, false, "language-nosuchlang"},
+		{"no language", `<pre><code>x := 1</code></pre>`, false, "<code>x := 1</code>"},
+	}
+	for _, test := range tests {
+		out, err := highlightCode(template.HTML(test.raw))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+			continue
+		}
+		got := string(out)
+		if highlighted := strings.Contains(got, `class="chroma"`); highlighted != test.highlighted {
+			t.Errorf("%s: highlighted = %t, want %t in %q", test.name, highlighted, test.highlighted, got)
+		}
+		if !test.highlighted && got != test.raw {
+			t.Errorf("%s: changed to %q, want it left alone", test.name, got)
+		}
+		if !strings.Contains(got, test.contains) {
+			t.Errorf("%s: %q does not contain %q", test.name, got, test.contains)
+		}
+	}
+}
+
+func TestHighlightCSS(t *testing.T) {
+	var buff bytes.Buffer
+	if err := HighlightCSS(&buff, "monokai"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(buff.String(), ".chroma") {
+		t.Errorf("stylesheet has no rules for .chroma")
+	}
+	if err := HighlightCSS(&buff, "nosuchstyle"); err == nil {
+		t.Error("expected an error for an unknown style")
+	}
+}
@@ -20,31 +20,44 @@ import (
 	"errors"
 	"github.com/DataDrake/static-cling/config"
 	"github.com/DataDrake/static-cling/file"
-	// "github.com/russross/blackfriday/v2"
-	"gopkg.in/yaml.v3"
+	"github.com/russross/blackfriday/v2"
+	"html/template"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"time"
 )
 
 // Page represents a single page to be rendered to the build tree
 type Page struct {
-	Title    string           `yaml:"title"`
-	Author   string           `yaml:"author"`
-	Date     time.Time        `yaml:"date"`
-	Category string           `yaml:"category"`
-	Vars     config.Variables `yaml:"vars"`
-	Content  string           `yaml:"-"`
-	file     *file.File
-	meta     *file.File
+	Title       string           `yaml:"title"`
+	Author      string           `yaml:"author"`
+	Date        time.Time        `yaml:"date"`
+	Category    string           `yaml:"category"`
+	Weight      int              `yaml:"weight"`
+	Sitemap     Sitemap          `yaml:"sitemap"`
+	Vars        config.Variables `yaml:"vars"`
+	Summary     template.HTML    `yaml:"summary"`
+	Content     template.HTML    `yaml:"-"`
+	Truncated   bool             `yaml:"-"`
+	TOC         TOC              `yaml:"-"`
+	WordCount   int              `yaml:"-"`
+	ReadingTime int              `yaml:"-"`
+	URL         string           `yaml:"-"`
+	Prev        *Page            `yaml:"-"`
+	Next        *Page            `yaml:"-"`
+	file        *file.File
+	meta        *file.File
+	opts        Options
 }
 
 // NewPage creates a Page record from a known File
-func NewPage(content *file.File) (p *Page, err error) {
+func NewPage(content *file.File, opts Options) (p *Page, err error) {
 	p = &Page{
 		file: content,
+		opts: opts,
 	}
-	p.meta = file.NewFile(content.Name+".yaml", content.Dir)
 	err = p.Update()
 	return
 }
@@ -52,17 +65,53 @@ func NewPage(content *file.File) (p *Page, err error) {
 // OutputPath provides the name and subdirectory for this Page in the build directory
 func (p *Page) OutputPath() (name, dir string, err error) {
 	name = p.file.Name + ".html"
-	wd, err := os.Getwd()
-	if err != nil {
-		return
+	dir = filepath.FromSlash(strings.Trim(p.URL[:strings.LastIndex(p.URL, "/")+1], "/"))
+	return
+}
+
+// Get retrieves a field of this Page by name, ignoring case, falling back to its variables
+func (p *Page) Get(key string) interface{} {
+	v := reflect.ValueOf(p).Elem()
+	field := v.FieldByNameFunc(func(name string) bool {
+		return strings.EqualFold(name, key)
+	})
+	if field.IsValid() && field.CanInterface() {
+		return field.Interface()
+	}
+	params := p.Vars.Params()
+	if value, ok := params[key]; ok {
+		return value
+	}
+	for name, value := range params {
+		if strings.EqualFold(name, key) {
+			return value
+		}
+	}
+	return nil
+}
+
+// Path gets the location of the content file for this Page, if it has one
+func (p *Page) Path() string {
+	if p.file == nil {
+		return ""
+	}
+	return p.file.Path()
+}
+
+// Modified gets the last time that either the metadata or content were modified
+func (p *Page) Modified() (modified time.Time) {
+	if p.file != nil {
+		modified = p.file.Modified
+	}
+	if p.meta != nil && p.meta.Modified.After(modified) {
+		modified = p.meta.Modified
 	}
-	dir, err = filepath.Rel(wd, p.file.Dir)
 	return
 }
 
 // IsNewer checks if either the metadata or content have been modified after a certain time
 func (p *Page) IsNewer(other time.Time) bool {
-	return p.file.Modified.After(other) || p.meta.Modified.After(other)
+	return p.file.Modified.After(other) || (p.meta != nil && p.meta.Modified.After(other))
 }
 
 // Update re-reads the content and metadata for this PAge
@@ -70,6 +119,23 @@ func (p *Page) Update() (err error) {
 	if err = p.updateContent(); err != nil {
 		return err
 	}
+	p.Summary = ""
+	if err = p.updateMeta(); err != nil {
+		return
+	}
+	p.updateSummary()
+	return
+}
+
+// updateMeta re-reads the metadata for this Page, if it exists
+func (p *Page) updateMeta() (err error) {
+	path, err := config.FindFileFS(p.file.FS, p.file.Dir, p.file.Name)
+	if err != nil {
+		return
+	}
+	if p.meta == nil || p.meta.Path() != path {
+		p.meta = file.NewFileFS(p.file.FS, p.file.Dir, filepath.Base(path))
+	}
 	if err = p.meta.Open(os.O_RDONLY); err != nil {
 		if !os.IsNotExist(err) {
 			return
@@ -78,8 +144,7 @@ func (p *Page) Update() (err error) {
 		return
 	}
 	defer p.meta.Close()
-	dec := yaml.NewDecoder(p.meta)
-	err = dec.Decode(p)
+	err = config.Decode(p.meta.Path(), p.meta, p, p.opts.Lenient)
 	return
 }
 
@@ -91,20 +156,26 @@ func (p *Page) updateContent() (err error) {
 		return
 	}
 	defer p.file.Close()
-	if p.Content, err = p.file.ReadString(); err != nil {
+	raw, err := p.file.ReadString()
+	if err != nil {
 		return
 	}
+	p.Content = template.HTML(raw)
 	switch p.file.Ext {
 	case ".html":
 		// already in HTML format
 	case ".md":
-		// out := blackfriday.Run([]byte(p.Content))
-		// p.Content = string(out)
-		fallthrough
+		p.Content = template.HTML(blackfriday.Run([]byte(raw)))
 	case ".haml", ".timber":
 		fallthrough
 	default:
 		err = ErrUnsupportedContent
+		return
+	}
+	if p.Content, err = highlightCode(p.Content); err != nil {
+		return
 	}
+	p.Content, p.TOC = anchorHeadings(p.Content)
+	p.updateReading()
 	return
 }
@@ -0,0 +1,61 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package content
+
+import (
+	"github.com/DataDrake/static-cling/config"
+	"github.com/DataDrake/static-cling/file"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestIsNewerWithoutMeta(t *testing.T) {
+	now := time.Now()
+	f := file.NewFile("content", "page.html")
+	f.Modified = now
+	p := &Page{file: f}
+	if !p.IsNewer(now.Add(-time.Hour)) {
+		t.Error("page should be newer than an hour ago")
+	}
+	if p.IsNewer(now.Add(time.Hour)) {
+		t.Error("page should not be newer than an hour from now")
+	}
+}
+
+func TestUpdateSidecarMeta(t *testing.T) {
+	fsys := fstest.MapFS{
+		"post.md":   {Data: []byte("Hello")},
+		"post.yaml": {Data: []byte("title: Post\ntitel: Typo\nweight: 3\n")},
+	}
+	f := file.NewFileFS(fsys, ".", "post.md")
+	_, err := NewPage(f, Options{})
+	errs, ok := err.(config.DecodeErrors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("error = %v, want a single DecodeError", err)
+	}
+	if got := errs[0].Location(); got != "post.yaml:2:1" {
+		t.Errorf("location = %q, want %q", got, "post.yaml:2:1")
+	}
+	page, err := NewPage(f, Options{Lenient: true})
+	if err != nil {
+		t.Fatalf("lenient: unexpected error: %s", err)
+	}
+	if page.Title != "Post" || page.Weight != 3 {
+		t.Errorf("lenient: title = %q, weight = %d, want %q and 3", page.Title, page.Weight, "Post")
+	}
+}
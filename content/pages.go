@@ -17,41 +17,291 @@
 package content
 
 import (
+	"fmt"
 	"sort"
+	"strings"
+	"time"
 )
 
 // Pages is a list of Pages
 type Pages []*Page
 
+// sorted gets a new list of these pages, ordered by a comparison function
+func (ps Pages) sorted(less func(a, b *Page) bool) Pages {
+	out := pageSorter{
+		pages: make(Pages, len(ps)),
+		less:  less,
+	}
+	copy(out.pages, ps)
+	sort.Stable(out)
+	return out.pages
+}
+
 // Latest gets a new list of these pages is order from newest to oldest
 func (ps Pages) Latest() Pages {
-	ls := latestPages(ps)
-	sort.Sort(ls)
-	return Pages(ls)
+	return ps.sorted(func(a, b *Page) bool {
+		return a.Date.After(b.Date)
+	})
+}
+
+// ByDate gets a new list of these pages in order from oldest to newest
+func (ps Pages) ByDate() Pages {
+	return ps.sorted(func(a, b *Page) bool {
+		return a.Date.Before(b.Date)
+	})
+}
+
+// Newest gets the most recent Date of any of these pages, or the zero time if there are none
+func (ps Pages) Newest() (newest time.Time) {
+	for _, page := range ps {
+		if page.Date.After(newest) {
+			newest = page.Date
+		}
+	}
+	return
+}
+
+// ByTitle gets a new list of these pages in alphabetical order by title
+func (ps Pages) ByTitle() Pages {
+	return ps.sorted(func(a, b *Page) bool {
+		return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+	})
+}
+
+// ByWeight gets a new list of these pages in order from lightest to heaviest
+func (ps Pages) ByWeight() Pages {
+	return ps.sorted(func(a, b *Page) bool {
+		return a.Weight < b.Weight
+	})
+}
+
+// ByParam gets a new list of these pages in ascending order of a field or variable
+func (ps Pages) ByParam(key string) Pages {
+	return ps.SortBy(key, false)
+}
+
+// SortBy gets a new list of these pages ordered by a field or variable, optionally in descending order
+func (ps Pages) SortBy(key string, desc bool) Pages {
+	return ps.sorted(func(a, b *Page) bool {
+		c := Compare(a.Get(key), b.Get(key))
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
 }
 
 // Reverse returns the Pages in reverse order (alphanumeric by default)
-func (ps Pages) Reverse() (out Pages) {
+func (ps Pages) Reverse() Pages {
+	out := make(Pages, len(ps))
+	for i, page := range ps {
+		out[len(ps)-1-i] = page
+	}
+	return out
+}
+
+// Where gets the pages where a field or variable matches a value
+func (ps Pages) Where(key string, value interface{}) (out Pages) {
 	for _, page := range ps {
-		out = append(Pages{page}, out...)
+		if Compare(page.Get(key), value) == 0 {
+			out = append(out, page)
+		}
 	}
 	return
 }
 
-// latestPages is a Pages sorted from Newest to Oldest
-type latestPages Pages
+// Limit gets up to the first "n" pages
+func (ps Pages) Limit(n int) Pages {
+	if n < 0 {
+		n = 0
+	}
+	if n > len(ps) {
+		n = len(ps)
+	}
+	return ps[:n]
+}
+
+// Offset gets the pages after skipping the first "n"
+func (ps Pages) Offset(n int) Pages {
+	if n < 0 {
+		n = 0
+	}
+	if n > len(ps) {
+		n = len(ps)
+	}
+	return ps[n:]
+}
+
+// index finds the position of a Page in this list, or -1 if it is missing
+func (ps Pages) index(p *Page) int {
+	for i, page := range ps {
+		if page == p {
+			return i
+		}
+	}
+	return -1
+}
+
+// Next gets the page after the specified Page, or nil if it is last or missing
+func (ps Pages) Next(p *Page) *Page {
+	if i := ps.index(p); i >= 0 && i < len(ps)-1 {
+		return ps[i+1]
+	}
+	return nil
+}
+
+// Prev gets the page before the specified Page, or nil if it is first or missing
+func (ps Pages) Prev(p *Page) *Page {
+	if i := ps.index(p); i > 0 {
+		return ps[i-1]
+	}
+	return nil
+}
+
+// PageGroup is a set of Pages which share the same value for a field
+type PageGroup struct {
+	Key   string
+	Pages Pages
+}
+
+// PageGroups is an ordered list of PageGroups
+type PageGroups []PageGroup
+
+// groupBy splits these pages into groups by key, in order of first appearance
+func (ps Pages) groupBy(key func(p *Page) string) (groups PageGroups) {
+	index := make(map[string]int)
+	for _, page := range ps {
+		name := key(page)
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, PageGroup{Key: name})
+		}
+		groups[i].Pages = append(groups[i].Pages, page)
+	}
+	return
+}
+
+// GroupBy splits these pages into groups by a field or variable
+func (ps Pages) GroupBy(key string) PageGroups {
+	return ps.groupBy(func(p *Page) string {
+		return fmt.Sprint(p.Get(key))
+	})
+}
+
+// GroupByYear splits these pages into groups by the year of their date (ie. "2021")
+func (ps Pages) GroupByYear() PageGroups {
+	return ps.groupBy(func(p *Page) string {
+		return p.Date.Format("2006")
+	})
+}
+
+// GroupByMonth splits these pages into groups by the month of their date (ie. "2021-03")
+func (ps Pages) GroupByMonth() PageGroups {
+	return ps.groupBy(func(p *Page) string {
+		return p.Date.Format("2006-01")
+	})
+}
+
+// GroupByCategory splits these pages into groups by Category
+func (ps Pages) GroupByCategory() PageGroups {
+	return ps.groupBy(func(p *Page) string {
+		return p.Category
+	})
+}
+
+// GroupByAuthor splits these pages into groups by Author
+func (ps Pages) GroupByAuthor() PageGroups {
+	return ps.groupBy(func(p *Page) string {
+		return p.Author
+	})
+}
+
+// Compare orders two field or variable values, returning -1, 0, or 1
+//
+// Dates and numbers of any type are compared by value, while anything else is compared as a string.
+func Compare(a, b interface{}) int {
+	switch x := a.(type) {
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			switch {
+			case x.Before(y):
+				return -1
+			case x.After(y):
+				return 1
+			}
+			return 0
+		}
+	default:
+		if x, ok := toFloat(a); ok {
+			if y, ok := toFloat(b); ok {
+				return compareFloat(x, y)
+			}
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// toFloat converts any integer or floating point number to a float64, so that they compare by value
+func toFloat(v interface{}) (f float64, ok bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// compareFloat orders two numbers, returning -1, 0, or 1
+func compareFloat(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// pageSorter sorts Pages with a comparison function
+type pageSorter struct {
+	pages Pages
+	less  func(a, b *Page) bool
+}
 
 // Len returns the length of the list (satisfies sort.Sort)
-func (ps latestPages) Len() int {
-	return len(ps)
+func (s pageSorter) Len() int {
+	return len(s.pages)
 }
 
-// Less is true if this Page is newer (satisfies sort.Sort)
-func (ps latestPages) Less(i, j int) bool {
-	return ps[j].Date.After(ps[i].Date)
+// Less is true if this Page should come first (satisfies sort.Sort)
+func (s pageSorter) Less(i, j int) bool {
+	return s.less(s.pages[i], s.pages[j])
 }
 
 // Swap the entries of the list (satisfies sort.Sort)
-func (ps latestPages) Swap(i, j int) {
-	ps[i], ps[j] = ps[j], ps[i]
+func (s pageSorter) Swap(i, j int) {
+	s.pages[i], s.pages[j] = s.pages[j], s.pages[i]
 }
@@ -0,0 +1,229 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package content
+
+import (
+	"github.com/DataDrake/static-cling/config"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		a, b interface{}
+		want int
+	}{
+		{10, 10.0, 0},
+		{10.0, 10, 0},
+		{int64(3), 2.5, 1},
+		{uint8(1), 2, -1},
+		{float32(1.5), 1.5, 0},
+		{9, 10, -1},
+		{"9", "10", 1},
+		{now, now.Add(time.Hour), -1},
+		{now, now, 0},
+	}
+	for _, test := range tests {
+		if got := Compare(test.a, test.b); got != test.want {
+			t.Errorf("Compare(%#v, %#v) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestNewest(t *testing.T) {
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	pages := Pages{{Date: old}, {Date: recent}, {}}
+	if got := pages.Newest(); !got.Equal(recent) {
+		t.Errorf("Newest() = %s, want %s", got, recent)
+	}
+	if got := (Pages{}).Newest(); !got.IsZero() {
+		t.Errorf("Newest() of no pages = %s, want the zero time", got)
+	}
+}
+
+func TestByWeight(t *testing.T) {
+	pages := Pages{
+		{Title: "c", Weight: 2},
+		{Title: "a", Weight: 1},
+		{Title: "d", Weight: 2},
+		{Title: "b", Weight: 1},
+		{Title: "first", Weight: -1},
+		{Title: "unweighted"},
+	}
+	want := "first unweighted a b c d"
+	var titles []string
+	for _, page := range pages.ByWeight() {
+		titles = append(titles, page.Title)
+	}
+	if got := strings.Join(titles, " "); got != want {
+		t.Errorf("ByWeight() = %s, want %s", got, want)
+	}
+	if pages[0].Title != "c" {
+		t.Error("ByWeight() reordered the original list")
+	}
+}
+
+// fixture creates a list of Pages, in no particular order
+func fixture() Pages {
+	date := func(year, month int) time.Time {
+		return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	}
+	vars := func(rank interface{}) config.Variables {
+		return config.Variables{Values: map[string]interface{}{"rank": rank}}
+	}
+	return Pages{
+		{Title: "banana", Date: date(2021, 3), Category: "Fruit", Author: "ann", Vars: vars(2)},
+		{Title: "Apple", Date: date(2020, 12), Category: "Fruit", Author: "bob", Vars: vars(10)},
+		{Title: "carrot", Date: date(2021, 1), Category: "Vegetable", Author: "ann", Vars: vars(1)},
+		{Title: "date", Date: date(2021, 3), Category: "Fruit", Author: "cat", Vars: vars(2.5)},
+	}
+}
+
+// titles lists the title of each Page, in order
+func titles(pages Pages) string {
+	var list []string
+	for _, page := range pages {
+		list = append(list, page.Title)
+	}
+	return strings.Join(list, " ")
+}
+
+func TestPagesSort(t *testing.T) {
+	pages := fixture()
+	tests := []struct {
+		name  string
+		pages Pages
+		want  string
+	}{
+		{"Latest", pages.Latest(), "banana date carrot Apple"},
+		{"ByDate", pages.ByDate(), "Apple carrot banana date"},
+		{"ByTitle", pages.ByTitle(), "Apple banana carrot date"},
+		{"ByParam(title)", pages.ByParam("title"), "Apple banana carrot date"},
+		{"ByParam(rank)", pages.ByParam("rank"), "carrot banana date Apple"},
+		{"SortBy(rank, desc)", pages.SortBy("rank", true), "Apple date banana carrot"},
+		{"SortBy(Date, desc)", pages.SortBy("Date", true), "banana date carrot Apple"},
+		{"SortBy(missing)", pages.SortBy("missing", false), "banana Apple carrot date"},
+		{"Reverse", pages.Reverse(), "date carrot Apple banana"},
+	}
+	for _, test := range tests {
+		if got := titles(test.pages); got != test.want {
+			t.Errorf("%s = %s, want %s", test.name, got, test.want)
+		}
+	}
+	if got := titles(pages); got != "banana Apple carrot date" {
+		t.Errorf("sorting modified the original list: %s", got)
+	}
+}
+
+func TestPagesWhere(t *testing.T) {
+	pages := fixture()
+	tests := []struct {
+		key   string
+		value interface{}
+		want  string
+	}{
+		{"category", "Fruit", "banana Apple date"},
+		{"Author", "ann", "banana carrot"},
+		{"rank", 2, "banana"},
+		{"rank", 2.5, "date"},
+		{"author", "nobody", ""},
+	}
+	for _, test := range tests {
+		if got := titles(pages.Where(test.key, test.value)); got != test.want {
+			t.Errorf("Where(%q, %v) = %s, want %s", test.key, test.value, got, test.want)
+		}
+	}
+}
+
+func TestPagesLimitOffset(t *testing.T) {
+	pages := fixture()
+	tests := []struct {
+		n      int
+		limit  string
+		offset string
+	}{
+		{-1, "", "banana Apple carrot date"},
+		{0, "", "banana Apple carrot date"},
+		{2, "banana Apple", "carrot date"},
+		{4, "banana Apple carrot date", ""},
+		{10, "banana Apple carrot date", ""},
+	}
+	for _, test := range tests {
+		if got := titles(pages.Limit(test.n)); got != test.limit {
+			t.Errorf("Limit(%d) = %s, want %s", test.n, got, test.limit)
+		}
+		if got := titles(pages.Offset(test.n)); got != test.offset {
+			t.Errorf("Offset(%d) = %s, want %s", test.n, got, test.offset)
+		}
+	}
+	if got := titles(pages.Offset(1).Limit(2)); got != "Apple carrot" {
+		t.Errorf("Offset(1).Limit(2) = %s, want Apple carrot", got)
+	}
+}
+
+func TestPagesPrevNext(t *testing.T) {
+	pages := fixture()
+	missing := &Page{Title: "missing"}
+	tests := []struct {
+		page       *Page
+		prev, next *Page
+	}{
+		{pages[0], nil, pages[1]},
+		{pages[1], pages[0], pages[2]},
+		{pages[3], pages[2], nil},
+		{missing, nil, nil},
+	}
+	for _, test := range tests {
+		if got := pages.Prev(test.page); got != test.prev {
+			t.Errorf("Prev(%s) = %v, want %v", test.page.Title, got, test.prev)
+		}
+		if got := pages.Next(test.page); got != test.next {
+			t.Errorf("Next(%s) = %v, want %v", test.page.Title, got, test.next)
+		}
+	}
+	if (Pages{}).Next(missing) != nil || (Pages{}).Prev(missing) != nil {
+		t.Error("expected no neighbours in an empty list")
+	}
+}
+
+func TestPagesGroup(t *testing.T) {
+	pages := fixture()
+	tests := []struct {
+		name   string
+		groups PageGroups
+		want   []string
+	}{
+		{"GroupByYear", pages.GroupByYear(), []string{"2021: banana carrot date", "2020: Apple"}},
+		{"GroupByMonth", pages.GroupByMonth(), []string{"2021-03: banana date", "2020-12: Apple", "2021-01: carrot"}},
+		{"GroupByCategory", pages.GroupByCategory(), []string{"Fruit: banana Apple date", "Vegetable: carrot"}},
+		{"GroupByAuthor", pages.GroupByAuthor(), []string{"ann: banana carrot", "bob: Apple", "cat: date"}},
+		{"GroupBy(rank)", pages.Latest().GroupBy("rank"), []string{"2: banana", "2.5: date", "1: carrot", "10: Apple"}},
+		{"GroupBy(missing)", pages.GroupBy("missing"), []string{"<nil>: banana Apple carrot date"}},
+	}
+	for _, test := range tests {
+		var got []string
+		for _, group := range test.groups {
+			got = append(got, group.Key+": "+titles(group.Pages))
+		}
+		if strings.Join(got, ", ") != strings.Join(test.want, ", ") {
+			t.Errorf("%s = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package content
+
+import (
+	"github.com/DataDrake/static-cling/config"
+	"unicode"
+)
+
+// isCJK checks if a character is written without spaces between words (Chinese, Japanese, Korean)
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// countWords counts the words in plain text, treating each CJK character as a word when enabled
+func countWords(text string, cjk bool) (count int) {
+	inWord := false
+	for _, r := range text {
+		switch {
+		case cjk && isCJK(r):
+			count++
+			inWord = false
+		case unicode.IsSpace(r):
+			inWord = false
+		case unicode.IsPunct(r) && !inWord:
+			// leading or standalone punctuation is not a word
+		case !inWord:
+			count++
+			inWord = true
+		}
+	}
+	return
+}
+
+// updateReading sets the word count and reading time (in minutes) of this Page, from its rendered text
+func (p *Page) updateReading() {
+	p.WordCount = countWords(PlainText(string(p.Content)), p.opts.Reading.CJK)
+	p.ReadingTime = readingTime(p.WordCount, p.opts.Reading.WordsPerMinute)
+}
+
+// readingTime estimates the minutes needed to read a number of words, rounding up to a whole minute
+//
+// Speeds of zero or less fall back to the default from config.NewReading.
+func readingTime(words, wpm int) int {
+	if words == 0 {
+		return 0
+	}
+	if wpm <= 0 {
+		wpm = config.NewReading().WordsPerMinute
+	}
+	return (words + wpm - 1) / wpm
+}
@@ -0,0 +1,88 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package content
+
+import (
+	"github.com/DataDrake/static-cling/config"
+	"github.com/DataDrake/static-cling/file"
+	"testing"
+	"testing/fstest"
+)
+
+func TestCountWords(t *testing.T) {
+	tests := []struct {
+		text string
+		cjk  bool
+		want int
+	}{
+		{"", true, 0},
+		{"Hello, world!", true, 2},
+		{"don't stop", true, 2},
+		{"— a dash first", true, 3},
+		{"日本語のテキスト", true, 8},
+		{"日本語のテキスト", false, 1},
+		{"Go 言語 rocks", true, 4},
+		{"Go 言語 rocks", false, 3},
+		{"한국어 문장", true, 5},
+	}
+	for _, test := range tests {
+		if got := countWords(test.text, test.cjk); got != test.want {
+			t.Errorf("countWords(%q, %t) = %d, want %d", test.text, test.cjk, got, test.want)
+		}
+	}
+}
+
+func TestReadingTime(t *testing.T) {
+	tests := []struct {
+		words, wpm, want int
+	}{
+		{0, 200, 0},
+		{1, 200, 1},
+		{200, 200, 1},
+		{201, 200, 2},
+		{450, 0, 3},
+		{100, -5, 1},
+		{10, 3, 4},
+	}
+	for _, test := range tests {
+		if got := readingTime(test.words, test.wpm); got != test.want {
+			t.Errorf("readingTime(%d, %d) = %d, want %d", test.words, test.wpm, got, test.want)
+		}
+	}
+}
+
+func TestPageReading(t *testing.T) {
+	fsys := fstest.MapFS{
+		"post.html": {Data: []byte("<p>one two <em>three</em> 四五</p>")},
+	}
+	tests := []struct {
+		reading      config.Reading
+		words, times int
+	}{
+		{config.Reading{WordsPerMinute: 2, CJK: true}, 5, 3},
+		{config.Reading{WordsPerMinute: 2, CJK: false}, 4, 2},
+	}
+	for _, test := range tests {
+		page, err := NewPage(file.NewFileFS(fsys, ".", "post.html"), Options{Reading: test.reading})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if page.WordCount != test.words || page.ReadingTime != test.times {
+			t.Errorf("reading %#v: words = %d, time = %d, want %d and %d", test.reading, page.WordCount, page.ReadingTime, test.words, test.times)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package content
+
+import (
+	"github.com/DataDrake/static-cling/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Sitemap overrides how a Page is listed in the sitemap
+//
+// In metadata, this may be either "sitemap: false" to leave the Page out entirely, or a
+// mapping with a "priority" and/or "changefreq".
+type Sitemap struct {
+	Exclude    bool    `yaml:"-"`
+	Priority   float64 `yaml:"priority"`
+	ChangeFreq string  `yaml:"changefreq"`
+}
+
+// UnmarshalYAML decodes either a boolean or a mapping (satisfies yaml.Unmarshaler)
+func (s *Sitemap) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var include bool
+		if err := node.Decode(&include); err != nil {
+			return err
+		}
+		s.Exclude = !include
+		return nil
+	}
+	type plain Sitemap
+	return node.Decode((*plain)(s))
+}
+
+// JSONSchema describes either form of Sitemap (satisfies schema.Schemer)
+func (s Sitemap) JSONSchema() *schema.Schema {
+	return &schema.Schema{
+		OneOf: []*schema.Schema{
+			{Type: "boolean"},
+			{
+				Type: "object",
+				Properties: map[string]*schema.Schema{
+					"priority":   {Type: "number"},
+					"changefreq": {Type: "string"},
+				},
+				AdditionalProperties: false,
+			},
+		},
+	}
+}
@@ -0,0 +1,85 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package content
+
+import (
+	"encoding/json"
+	"github.com/DataDrake/static-cling/config"
+	"github.com/DataDrake/static-cling/schema"
+	"strings"
+	"testing"
+)
+
+func TestPageSchema(t *testing.T) {
+	s := schema.For(Page{})
+	if s.Title != "Page" || s.AdditionalProperties != false {
+		t.Errorf("unexpected root: %s %v", s.Title, s.AdditionalProperties)
+	}
+	want := map[string]string{
+		"title":    `{"type":"string"}`,
+		"date":     `{"type":"string"}`,
+		"weight":   `{"type":"integer"}`,
+		"author":   `{"type":"string"}`,
+		"category": `{"type":"string"}`,
+		"summary":  `{"type":"string"}`,
+		"vars":     `{"$ref":"#/$defs/Variables"}`,
+		"sitemap":  `{"oneOf":[{"type":"boolean"},{"type":"object","properties":{"changefreq":{"type":"string"},"priority":{"type":"number"}},"additionalProperties":false}]}`,
+	}
+	for name, expected := range want {
+		raw, err := json.Marshal(s.Properties[name])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(raw); got != expected {
+			t.Errorf("%s = %s, want %s", name, got, expected)
+		}
+	}
+	// generated while rendering, rather than read from metadata
+	for _, name := range []string{"content", "url", "toc", "prev", "next"} {
+		if _, ok := s.Properties[name]; ok {
+			t.Errorf("unexpected property %q", name)
+		}
+	}
+}
+
+func TestSitemapDecode(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Sitemap
+		err  string
+	}{
+		{"title: x\n", Sitemap{}, ""},
+		{"sitemap: true\n", Sitemap{}, ""},
+		{"sitemap: false\n", Sitemap{Exclude: true}, ""},
+		{"sitemap:\n    priority: 0.8\n    changefreq: weekly\n", Sitemap{Priority: 0.8, ChangeFreq: "weekly"}, ""},
+		{"sitemap: often\n", Sitemap{}, "page.yaml:1:10: sitemap: expected a boolean or a mapping, found a string"},
+		{"sitemap:\n    priority: high\n", Sitemap{}, "page.yaml:2:15: sitemap.priority: expected a number, found a string"},
+		{"sitemap:\n    often: true\n", Sitemap{}, `page.yaml:2:5: sitemap: unknown key "often"`},
+	}
+	for _, test := range tests {
+		var p Page
+		err := config.Decode("page.yaml", strings.NewReader(test.raw), &p, false)
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%q: unexpected error: %s", test.raw, err)
+		case test.err != "" && (err == nil || err.Error() != test.err):
+			t.Errorf("%q: error = %v, want %q", test.raw, err, test.err)
+		case test.err == "" && p.Sitemap != test.want:
+			t.Errorf("%q: sitemap = %+v, want %+v", test.raw, p.Sitemap, test.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package content
+
+import (
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// SummaryMarker separates the summary of a Page from the rest of its content
+const SummaryMarker = "<!--more-->"
+
+// SummaryWords is the length of a generated summary, when there is no marker or "summary" metadata
+var SummaryWords = 70
+
+// tagPattern matches any HTML tag
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// PlainText removes all HTML tags and entities from a string
+func PlainText(s string) string {
+	return html.UnescapeString(tagPattern.ReplaceAllString(s, " "))
+}
+
+// updateSummary sets the Summary of this Page, in order of preference from:
+//
+//  1. The "summary" metadata field
+//  2. Any content before the SummaryMarker
+//  3. The first SummaryWords words of the content
+func (p *Page) updateSummary() {
+	raw := string(p.Content)
+	p.Truncated = false
+	switch i := strings.Index(raw, SummaryMarker); {
+	case p.Summary != "":
+		p.Truncated = true
+	case i >= 0:
+		p.Summary = template.HTML(raw[:i])
+		p.Truncated = true
+	default:
+		words := strings.Fields(PlainText(raw))
+		if len(words) > SummaryWords {
+			words = words[:SummaryWords]
+			p.Truncated = true
+		}
+		p.Summary = template.HTML(template.HTMLEscapeString(strings.Join(words, " ")))
+	}
+}
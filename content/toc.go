@@ -0,0 +1,161 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package content
+
+import (
+	"fmt"
+	"github.com/DataDrake/static-cling/util"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"html/template"
+	"strings"
+)
+
+// Heading is a single entry in a table of contents
+type Heading struct {
+	ID       string
+	Title    string
+	Level    int
+	Children TOC
+}
+
+// TOC is a table of contents, nested by heading level
+type TOC []*Heading
+
+// headingLevels maps each heading element to its level
+var headingLevels = map[atom.Atom]int{
+	atom.H1: 1,
+	atom.H2: 2,
+	atom.H3: 3,
+	atom.H4: 4,
+	atom.H5: 5,
+	atom.H6: 6,
+}
+
+// anchorHeadings gives every heading in rendered HTML a stable ID and builds a table of contents
+//
+// Existing IDs are kept. Otherwise, the ID is generated from the text of the heading, with a
+// numbered suffix when the same text appears more than once or the ID is used anywhere else.
+func anchorHeadings(raw template.HTML) (out template.HTML, toc TOC) {
+	var b, inner, title strings.Builder
+	var stack []*Heading
+	var start *html.Token
+	used := explicitIDs(raw)
+	z := html.NewTokenizer(strings.NewReader(string(raw)))
+	for tt := z.Next(); tt != html.ErrorToken; tt = z.Next() {
+		// Raw must be copied first, because reading a token unescapes it in place
+		raw := string(z.Raw())
+		if start == nil {
+			tok := z.Token()
+			if tt != html.StartTagToken || headingLevels[tok.DataAtom] == 0 {
+				b.WriteString(raw)
+				continue
+			}
+			// hold the start tag until the ID is known
+			start = &tok
+			inner.Reset()
+			title.Reset()
+			continue
+		}
+		if tt == html.EndTagToken {
+			if name, _ := z.TagName(); atom.Lookup(name) == start.DataAtom {
+				h := newHeading(start, title.String(), used)
+				toc, stack = appendHeading(toc, stack, h)
+				b.WriteString(start.String())
+				b.WriteString(inner.String())
+				b.WriteString(raw)
+				start = nil
+				continue
+			}
+		}
+		inner.WriteString(raw)
+		if tt == html.TextToken {
+			title.Write(z.Text())
+		}
+	}
+	if start != nil {
+		// unterminated heading, leave it as-is
+		b.WriteString(start.String())
+		b.WriteString(inner.String())
+	}
+	out = template.HTML(b.String())
+	return
+}
+
+// explicitIDs finds every ID set in rendered HTML, so that generated IDs can avoid them
+func explicitIDs(raw template.HTML) map[string]int {
+	used := make(map[string]int)
+	z := html.NewTokenizer(strings.NewReader(string(raw)))
+	for tt := z.Next(); tt != html.ErrorToken; tt = z.Next() {
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+		for _, attr := range z.Token().Attr {
+			if attr.Key == "id" && attr.Val != "" {
+				used[attr.Val]++
+			}
+		}
+	}
+	return used
+}
+
+// newHeading creates a Heading from its start tag and text, setting the ID of the tag if missing
+func newHeading(start *html.Token, text string, used map[string]int) *Heading {
+	h := &Heading{
+		Title: strings.Join(strings.Fields(text), " "),
+		Level: headingLevels[start.DataAtom],
+	}
+	for _, attr := range start.Attr {
+		if attr.Key == "id" {
+			h.ID = attr.Val
+		}
+	}
+	if h.ID != "" {
+		// already reserved by explicitIDs
+		return h
+	}
+	h.ID = uniqueID(used, util.Slugify(h.Title))
+	start.Attr = append(start.Attr, html.Attribute{Key: "id", Val: h.ID})
+	return h
+}
+
+// uniqueID adds the first numbered suffix which has not been used to an ID which has already been used
+func uniqueID(used map[string]int, id string) string {
+	if id == "" {
+		id = "section"
+	}
+	unique := id
+	for n := 1; used[unique] > 0; n++ {
+		unique = fmt.Sprintf("%s-%d", id, n)
+	}
+	used[unique]++
+	return unique
+}
+
+// appendHeading adds a Heading beneath the closest preceding Heading of a lower level
+func appendHeading(toc TOC, stack []*Heading, h *Heading) (TOC, []*Heading) {
+	for len(stack) > 0 && stack[len(stack)-1].Level >= h.Level {
+		stack = stack[:len(stack)-1]
+	}
+	if len(stack) == 0 {
+		toc = append(toc, h)
+	} else {
+		parent := stack[len(stack)-1]
+		parent.Children = append(parent.Children, h)
+	}
+	return toc, append(stack, h)
+}
@@ -0,0 +1,40 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package content
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func TestAnchorHeadingsIDs(t *testing.T) {
+	raw := template.HTML(`<h2>Intro</h2><h2>Intro</h2><h2 id="intro">Later</h2><p id="intro-1">Text</p><h2>Setup</h2>`)
+	out, toc := anchorHeadings(raw)
+	want := []string{"intro-2", "intro-3", "intro", "setup"}
+	if len(toc) != len(want) {
+		t.Fatalf("found %d headings, want %d", len(toc), len(want))
+	}
+	for i, h := range toc {
+		if h.ID != want[i] {
+			t.Errorf("heading %d id = %q, want %q", i, h.ID, want[i])
+		}
+		if n := strings.Count(string(out), `id="`+h.ID+`"`); n != 1 {
+			t.Errorf("id %q used %d times, want 1", h.ID, n)
+		}
+	}
+}
@@ -16,6 +16,11 @@
 
 package content
 
+import (
+	"github.com/DataDrake/static-cling/config"
+	"github.com/DataDrake/static-cling/file"
+)
+
 // Tree contains all of the content
 type Tree struct {
 	Root *Dir
@@ -32,3 +37,24 @@ func NewTree(path string) (t *Tree, err error) {
 	}
 	return
 }
+
+// Options control how Pages are read from the content Tree
+type Options struct {
+	// Reading configures the word count and reading time of each Page
+	Reading config.Reading
+	// Lenient allows unknown keys in Page metadata, instead of rejecting them
+	Lenient bool
+}
+
+// Load reads the content Tree from a Source, on disk or in an fs.FS, including every Page
+func Load(src file.Source, opts Options) (t *Tree, err error) {
+	dir, err := src.Dir()
+	if err != nil {
+		return
+	}
+	t = &Tree{
+		Root: newDir(dir, "/", opts),
+	}
+	err = t.Root.update(true)
+	return
+}
@@ -0,0 +1,60 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package content
+
+import (
+	"github.com/DataDrake/static-cling/file"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"content/index.html":       {Data: []byte("<p>Home</p>")},
+		"content/blog/first.md":    {Data: []byte("# First\n\nHello")},
+		"content/blog/first.yaml":  {Data: []byte("title: First Post\nweight: 2\n")},
+		"content/blog/second.md":   {Data: []byte("Second")},
+		"content/blog/second.yaml": {Data: []byte("title: Second Post\nweight: 1\n")},
+		"content/blog/notes.txt":   {Data: []byte("skipped")},
+	}
+	tree, err := Load(file.NewSource(fsys, "test").Join("content"), Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(tree.Root.Pages) != 1 || tree.Root.Pages[0].URL != "/" {
+		t.Fatalf("root pages = %v, want only the index", tree.Root.Pages)
+	}
+	blog, ok := tree.Root.Subs["blog"]
+	if !ok {
+		t.Fatal("missing the blog directory")
+	}
+	want := []struct{ title, url string }{
+		{"Second Post", "/blog/second.html"},
+		{"First Post", "/blog/first.html"},
+	}
+	if len(blog.Pages) != len(want) {
+		t.Fatalf("found %d blog pages, want %d", len(blog.Pages), len(want))
+	}
+	for i, page := range blog.Pages {
+		if page.Title != want[i].title || page.URL != want[i].url {
+			t.Errorf("page %d = %q at %q, want %q at %q", i, page.Title, page.URL, want[i].title, want[i].url)
+		}
+	}
+	if blog.Pages[0].Next != blog.Pages[1] || blog.Pages[1].Prev != blog.Pages[0] {
+		t.Error("blog pages are not linked in order")
+	}
+}
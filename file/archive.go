@@ -0,0 +1,210 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package file
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// memFS is a read-only fs.FS held entirely in memory, used for the contents of tar archives
+type memFS map[string]*memEntry
+
+// memEntry is a single file or directory in a memFS
+type memEntry struct {
+	name     string
+	data     []byte
+	mode     fs.FileMode
+	modified time.Time
+	children []string
+}
+
+// readTar loads a tar archive, optionally gzipped, into memory
+func readTar(name string) (fsys memFS, err error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if strings.HasSuffix(name, ".gz") || strings.HasSuffix(name, ".tgz") {
+		var gz *gzip.Reader
+		if gz, err = gzip.NewReader(f); err != nil {
+			return
+		}
+		defer gz.Close()
+		r = gz
+	}
+	fsys = memFS{
+		".": &memEntry{name: ".", mode: fs.ModeDir | 0555},
+	}
+	tr := tar.NewReader(r)
+	for {
+		var hdr *tar.Header
+		if hdr, err = tr.Next(); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return
+		}
+		clean := path.Clean(strings.TrimPrefix(hdr.Name, "/"))
+		if clean == "." || !fs.ValidPath(clean) {
+			continue
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			fsys.mkdirAll(clean, hdr.ModTime)
+		case tar.TypeReg:
+			var data []byte
+			if data, err = io.ReadAll(tr); err != nil {
+				return
+			}
+			fsys.mkdirAll(path.Dir(clean), hdr.ModTime)
+			fsys.add(&memEntry{
+				name:     clean,
+				data:     data,
+				mode:     fs.FileMode(hdr.Mode).Perm(),
+				modified: hdr.ModTime,
+			})
+		}
+	}
+}
+
+// add records an entry, linking it to its parent directory
+func (m memFS) add(entry *memEntry) {
+	if _, ok := m[entry.name]; !ok {
+		parent := m[path.Dir(entry.name)]
+		parent.children = append(parent.children, entry.name)
+	}
+	m[entry.name] = entry
+}
+
+// mkdirAll records a directory and any of its missing parents
+func (m memFS) mkdirAll(name string, modified time.Time) {
+	if _, ok := m[name]; ok {
+		return
+	}
+	m.mkdirAll(path.Dir(name), modified)
+	m.add(&memEntry{
+		name:     name,
+		mode:     fs.ModeDir | 0555,
+		modified: modified,
+	})
+}
+
+// Open opens a file or directory by name (satisfies fs.FS)
+func (m memFS) Open(name string) (fs.File, error) {
+	entry, ok := m[name]
+	if !ok || !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	f := &memFile{
+		memEntry: entry,
+		Reader:   bytes.NewReader(entry.data),
+	}
+	if entry.mode.IsDir() {
+		sort.Strings(entry.children)
+		for _, child := range entry.children {
+			f.entries = append(f.entries, m[child])
+		}
+	}
+	return f, nil
+}
+
+// memFile is an open memEntry
+type memFile struct {
+	*memEntry
+	*bytes.Reader
+	entries []*memEntry
+}
+
+// Stat describes this file (satisfies fs.File)
+func (f *memFile) Stat() (fs.FileInfo, error) {
+	return f.memEntry, nil
+}
+
+// Close does nothing, since the file is in memory (satisfies fs.File)
+func (f *memFile) Close() error {
+	return nil
+}
+
+// ReadDir lists the next n entries of a directory, or all of them when n <= 0 (satisfies fs.ReadDirFile)
+func (f *memFile) ReadDir(n int) (entries []fs.DirEntry, err error) {
+	if !f.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: f.name, Err: fs.ErrInvalid}
+	}
+	if n > 0 && len(f.entries) == 0 {
+		return nil, io.EOF
+	}
+	count := len(f.entries)
+	if n > 0 && n < count {
+		count = n
+	}
+	for _, entry := range f.entries[:count] {
+		entries = append(entries, entry)
+	}
+	f.entries = f.entries[count:]
+	return
+}
+
+// Name gets the base name of this entry (satisfies fs.FileInfo and fs.DirEntry)
+func (e *memEntry) Name() string {
+	return path.Base(e.name)
+}
+
+// Size gets the length of the contents of this entry (satisfies fs.FileInfo)
+func (e *memEntry) Size() int64 {
+	return int64(len(e.data))
+}
+
+// Mode gets the file mode bits of this entry (satisfies fs.FileInfo)
+func (e *memEntry) Mode() fs.FileMode {
+	return e.mode
+}
+
+// ModTime gets the modification time of this entry (satisfies fs.FileInfo)
+func (e *memEntry) ModTime() time.Time {
+	return e.modified
+}
+
+// IsDir checks if this entry is a directory (satisfies fs.FileInfo and fs.DirEntry)
+func (e *memEntry) IsDir() bool {
+	return e.mode.IsDir()
+}
+
+// Sys provides no underlying data source (satisfies fs.FileInfo)
+func (e *memEntry) Sys() interface{} {
+	return nil
+}
+
+// Type gets the type bits of this entry (satisfies fs.DirEntry)
+func (e *memEntry) Type() fs.FileMode {
+	return e.mode.Type()
+}
+
+// Info describes this entry (satisfies fs.DirEntry)
+func (e *memEntry) Info() (fs.FileInfo, error) {
+	return e, nil
+}
@@ -17,29 +17,31 @@
 package file
 
 import (
-	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
 )
 
-var (
-	// ErrDirectoryCollision occurs when merging directories with the name subdir names
-	ErrDirectoryCollision = errors.New("tried to merge directories with identically named subdirs")
-	// ErrFileCollision occurs when merging directories with the name file names
-	ErrFileCollision = errors.New("tried to merge firectories with identically named files")
-)
-
-// Dir represents a generic directory on Disk
+// Dir represents a generic directory on Disk, or in an fs.FS if set
 type Dir struct {
-	Path  string
-	Dirs  map[string]*Dir
-	Files map[string]*File
+	Path   string
+	FS     fs.FS
+	Dirs   map[string]*Dir
+	Files  map[string]*File
+	layers []*Dir
+	policy MergePolicy
 }
 
 // NewDir creates a Dir from the specified path, recursively
 func NewDir(path string) (d *Dir, err error) {
+	return NewDirFS(nil, path)
+}
+
+// NewDirFS creates a Dir from the specified path inside of an fs.FS, recursively
+func NewDirFS(fsys fs.FS, path string) (d *Dir, err error) {
 	d = &Dir{
 		Path:  path,
+		FS:    fsys,
 		Dirs:  make(map[string]*Dir),
 		Files: make(map[string]*File),
 	}
@@ -47,38 +49,12 @@ func NewDir(path string) (d *Dir, err error) {
 	return
 }
 
-// Merge combines two directories as if they shared the same root
-func (d *Dir) Merge(other *Dir) (next *Dir, err error) {
-	next = &Dir{
-		Dirs:  make(map[string]*Dir),
-		Files: make(map[string]*File),
-	}
-	if err = next.merge(d); err != nil {
-		return
-	}
-	err = next.merge(other)
-	return
-}
-
-// merge adds these
-func (d *Dir) merge(other *Dir) error {
-	for name, entry := range other.Dirs {
-		if _, ok := d.Dirs[name]; !ok {
-			return ErrDirectoryCollision
-		}
-		d.Dirs[name] = entry
-	}
-	for name, entry := range other.Files {
-		if _, ok := d.Files[name]; !ok {
-			return ErrFileCollision
-		}
-		d.Files[name] = entry
-	}
-	return nil
-}
-
 // Mkdir creates a new directory immediately inside of this directory
 func (d *Dir) Mkdir(name string) (dir *Dir, err error) {
+	if d.FS != nil {
+		err = ErrReadOnly
+		return
+	}
 	path := filepath.Join(d.Path, name)
 	if err = os.Mkdir(path, 0755); err != nil {
 		return
@@ -92,7 +68,10 @@ func (d *Dir) Mkdir(name string) (dir *Dir, err error) {
 
 // Read updates the contents of this directory from disk
 func (d *Dir) Read() (err error) {
-	entries, err := os.ReadDir(d.Path)
+	if len(d.layers) > 0 {
+		return d.reread()
+	}
+	entries, err := ReadDir(d.FS, d.Path)
 	if err != nil {
 		return
 	}
@@ -168,7 +147,7 @@ func (d *Dir) updateDir(dir, name string) (err error) {
 	if ok {
 		err = next.Read()
 	} else {
-		next, err = NewDir(filepath.Join(dir, name))
+		next, err = NewDirFS(d.FS, Join(d.FS, dir, name))
 		d.Dirs[name] = next
 	}
 	return
@@ -178,7 +157,7 @@ func (d *Dir) updateDir(dir, name string) (err error) {
 func (d *Dir) updateFile(dir, name string) (err error) {
 	next, ok := d.Files[name]
 	if !ok {
-		next = NewFile(dir, name)
+		next = NewFileFS(d.FS, dir, name)
 		d.Files[name] = next
 	}
 	_, err = next.Stat()
@@ -187,6 +166,9 @@ func (d *Dir) updateFile(dir, name string) (err error) {
 
 // RemoveAll recursively removes a subdirectory from disk
 func (d *Dir) RemoveAll(name string) error {
+	if d.FS != nil {
+		return ErrReadOnly
+	}
 	if err := os.RemoveAll(filepath.Join(d.Path, name)); err != nil {
 		return err
 	}
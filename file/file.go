@@ -21,7 +21,6 @@ import (
 	"io"
 	"io/fs"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 )
@@ -35,14 +34,15 @@ var (
 	ErrNotOpen = errors.New("fie has not been opened")
 )
 
-// File is a generic representation of a File on disk
+// File is a generic representation of a File on disk, or in an fs.FS if set
 type File struct {
 	Name     string
 	Ext      string
 	Dir      string
 	Mode     fs.FileMode
 	Modified time.Time
-	f        *os.File
+	FS       fs.FS
+	f        fs.File
 }
 
 // NewFile creates a new File from the specified path
@@ -52,6 +52,13 @@ func NewFile(dir, name string) (f *File) {
 	return f
 }
 
+// NewFileFS creates a new File from the specified path inside of an fs.FS
+func NewFileFS(fsys fs.FS, dir, name string) (f *File) {
+	f = NewFile(dir, name)
+	f.FS = fsys
+	return f
+}
+
 // Init handles the setup for a new File
 func (f *File) Init(dir, name string) {
 	pieces := strings.SplitN(name, ".", 2)
@@ -85,6 +92,14 @@ func (f *File) openFile(flag int, mode os.FileMode) (err error) {
 		err = ErrFileOpen
 		return
 	}
+	if f.FS != nil {
+		if writable(flag) {
+			err = ErrReadOnly
+			return
+		}
+		f.f, err = f.FS.Open(f.Path())
+		return
+	}
 	f.f, err = os.OpenFile(f.Path(), flag, mode)
 	return
 }
@@ -95,10 +110,11 @@ func (f *File) Close() (err error) {
 		err = ErrAlreadyClosed
 		return
 	}
-	if err = f.f.Close(); err == nil {
+	err = f.f.Close()
+	f.f = nil
+	if err != nil {
 		return
 	}
-	f.f = nil
 	_, err = f.Stat()
 	return
 }
@@ -154,19 +170,24 @@ func (f *File) Write(p []byte) (n int, err error) {
 		err = ErrNotOpen
 		return
 	}
-	return f.f.Write(p)
+	w, ok := f.f.(io.Writer)
+	if !ok {
+		err = ErrReadOnly
+		return
+	}
+	return w.Write(p)
 }
 
 // Path gets the full filepath of the underlying file
 func (f *File) Path() string {
-	return filepath.Join(f.Dir, f.Name+f.Ext)
+	return Join(f.FS, f.Dir, f.Name+f.Ext)
 }
 
 // Stat updates the metadata for this File in memory
 func (f *File) Stat() (changed bool, err error) {
 	var info fs.FileInfo
 	if f.f == nil {
-		info, err = os.Stat(f.Path())
+		info, err = Stat(f.FS, f.Path())
 	} else {
 		info, err = f.f.Stat()
 	}
@@ -0,0 +1,120 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package file
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+var (
+	// ErrDirectoryCollision occurs when merging directories where a file and a subdir have the same name
+	ErrDirectoryCollision = errors.New("tried to merge a directory with an identically named file")
+	// ErrFileCollision occurs when merging directories with the same file names
+	ErrFileCollision = errors.New("tried to merge directories with identically named files")
+)
+
+// MergePolicy decides which entry to keep when merged directories both have one with the same name
+type MergePolicy int
+
+const (
+	// MergeError fails the merge on any collision
+	MergeError MergePolicy = iota
+	// PreferLeft keeps the entry from the directory being merged into
+	PreferLeft
+	// PreferRight keeps the entry from the directory being merged in, like an overlay
+	PreferRight
+)
+
+// Merge combines two directories as if they shared the same root, recursively
+//
+// Subdirectories with the same name are merged in turn, while any other collision is resolved by
+// the MergePolicy. The result has the Path of the other directory, and reading it again rereads and
+// remerges both.
+func (d *Dir) Merge(other *Dir, policy MergePolicy) (next *Dir, err error) {
+	next = &Dir{
+		Path:   other.Path,
+		FS:     other.FS,
+		layers: []*Dir{d, other},
+		policy: policy,
+	}
+	err = next.merge()
+	return
+}
+
+// reread updates each of the layers of a merged directory from disk, before merging them again
+func (d *Dir) reread() error {
+	for _, layer := range d.layers {
+		if err := layer.Read(); err != nil {
+			return err
+		}
+	}
+	return d.merge()
+}
+
+// merge rebuilds the contents of this directory from its layers
+func (d *Dir) merge() error {
+	left, right := d.layers[0], d.layers[1]
+	dirs := make(map[string]*Dir)
+	files := make(map[string]*File)
+	for name, dir := range left.Dirs {
+		dirs[name] = dir
+	}
+	for name, f := range left.Files {
+		files[name] = f
+	}
+	for name, dir := range right.Dirs {
+		if _, ok := files[name]; ok {
+			if d.policy == MergeError {
+				return fmt.Errorf("%w: %s", ErrDirectoryCollision, filepath.Join(right.Path, name))
+			}
+			if d.policy == PreferLeft {
+				continue
+			}
+			delete(files, name)
+		}
+		if prev, ok := dirs[name]; ok {
+			merged, err := prev.Merge(dir, d.policy)
+			if err != nil {
+				return err
+			}
+			dir = merged
+		}
+		dirs[name] = dir
+	}
+	for name, f := range right.Files {
+		_, isDir := dirs[name]
+		_, isFile := files[name]
+		if isDir || isFile {
+			switch d.policy {
+			case MergeError:
+				if isDir {
+					return fmt.Errorf("%w: %s", ErrDirectoryCollision, f.Path())
+				}
+				return fmt.Errorf("%w: %s", ErrFileCollision, f.Path())
+			case PreferLeft:
+				continue
+			}
+			delete(dirs, name)
+		}
+		files[name] = f
+	}
+	d.Dirs = dirs
+	d.Files = files
+	return nil
+}
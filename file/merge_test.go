@@ -0,0 +1,161 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package file
+
+import (
+	"errors"
+	"io/fs"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// newTestDir creates a Dir from a list of slash-separated files, where a trailing slash makes an empty directory
+func newTestDir(t *testing.T, names ...string) (*Dir, fstest.MapFS) {
+	fsys := make(fstest.MapFS)
+	for _, name := range names {
+		if strings.HasSuffix(name, "/") {
+			fsys[strings.TrimSuffix(name, "/")] = &fstest.MapFile{Mode: fs.ModeDir}
+			continue
+		}
+		fsys[name] = &fstest.MapFile{Data: []byte(name)}
+	}
+	d, err := NewDirFS(fsys, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d, fsys
+}
+
+// listing describes the contents of a Dir recursively, with a trailing slash on directories
+func listing(d *Dir, prefix string) (names []string) {
+	for name, sub := range d.Dirs {
+		names = append(names, prefix+name+"/")
+		names = append(names, listing(sub, prefix+name+"/")...)
+	}
+	for name := range d.Files {
+		names = append(names, prefix+name)
+	}
+	sort.Strings(names)
+	return
+}
+
+func TestMergeDisjoint(t *testing.T) {
+	// a regression test for merges which failed unless every entry collided
+	want := "a.html b.html other/ other/y.html sub/ sub/x.html"
+	for _, policy := range []MergePolicy{MergeError, PreferLeft, PreferRight} {
+		left, _ := newTestDir(t, "a.html", "sub/x.html")
+		right, _ := newTestDir(t, "b.html", "other/y.html")
+		merged, err := left.Merge(right, policy)
+		if err != nil {
+			t.Errorf("policy %d: unexpected error: %s", policy, err)
+			continue
+		}
+		if got := strings.Join(listing(merged, ""), " "); got != want {
+			t.Errorf("policy %d: merged = %q, want %q", policy, got, want)
+		}
+	}
+}
+
+func TestMergePolicies(t *testing.T) {
+	tests := []struct {
+		policy MergePolicy
+		err    error
+		from   string
+	}{
+		{MergeError, ErrFileCollision, ""},
+		{PreferLeft, nil, "left"},
+		{PreferRight, nil, "right"},
+	}
+	for _, test := range tests {
+		left, _ := newTestDir(t, "sub/deep/x.html", "sub/same.html")
+		right, _ := newTestDir(t, "sub/deep/y.html", "sub/same.html")
+		merged, err := left.Merge(right, test.policy)
+		if !errors.Is(err, test.err) {
+			t.Errorf("policy %d: error = %v, want %v", test.policy, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		want := "sub/ sub/deep/ sub/deep/x.html sub/deep/y.html sub/same.html"
+		if got := strings.Join(listing(merged, ""), " "); got != want {
+			t.Errorf("policy %d: merged = %q, want %q", test.policy, got, want)
+		}
+		kept := merged.Dirs["sub"].Files["same.html"]
+		from := map[*File]string{
+			left.Dirs["sub"].Files["same.html"]:  "left",
+			right.Dirs["sub"].Files["same.html"]: "right",
+		}[kept]
+		if from != test.from {
+			t.Errorf("policy %d: kept the %s file, want the %s file", test.policy, from, test.from)
+		}
+	}
+}
+
+func TestMergeDirectoryCollision(t *testing.T) {
+	tests := []struct {
+		policy  MergePolicy
+		err     error
+		want    string
+		reverse bool
+	}{
+		{MergeError, ErrDirectoryCollision, "", false},
+		{PreferLeft, nil, "x", false},
+		{PreferRight, nil, "x/ x/y.html", false},
+		{MergeError, ErrDirectoryCollision, "", true},
+		{PreferLeft, nil, "x/ x/y.html", true},
+		{PreferRight, nil, "x", true},
+	}
+	for _, test := range tests {
+		file, _ := newTestDir(t, "x")
+		dir, _ := newTestDir(t, "x/y.html")
+		left, right := file, dir
+		if test.reverse {
+			left, right = dir, file
+		}
+		merged, err := left.Merge(right, test.policy)
+		if !errors.Is(err, test.err) {
+			t.Errorf("policy %d, reversed: %t: error = %v, want %v", test.policy, test.reverse, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got := strings.Join(listing(merged, ""), " "); got != test.want {
+			t.Errorf("policy %d, reversed: %t: merged = %q, want %q", test.policy, test.reverse, got, test.want)
+		}
+	}
+}
+
+func TestMergeReread(t *testing.T) {
+	left, _ := newTestDir(t, "sub/x.html")
+	right, fsys := newTestDir(t, "sub/y.html")
+	merged, err := left.Merge(right, PreferRight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys["sub/z.html"] = &fstest.MapFile{}
+	if err = merged.Read(); err != nil {
+		t.Fatal(err)
+	}
+	want := "sub/ sub/x.html sub/y.html sub/z.html"
+	if got := strings.Join(listing(merged, ""), " "); got != want {
+		t.Errorf("merged = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,129 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package file
+
+import (
+	"archive/zip"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// Source is the root of a tree of files, either on disk or inside of an fs.FS such as an archive or embed.FS
+type Source struct {
+	FS   fs.FS
+	Path string
+	name string
+}
+
+// NewSource creates a Source for a path inside of an fs.FS, using a name to describe it
+func NewSource(fsys fs.FS, name string) Source {
+	return Source{
+		FS:   fsys,
+		Path: ".",
+		name: name,
+	}
+}
+
+// archiveExts are the supported archive formats, by file extension
+var archiveExts = []string{".zip", ".tar", ".tar.gz", ".tgz"}
+
+// IsArchive checks if a path has the extension of a supported archive format
+func IsArchive(path string) bool {
+	for _, ext := range archiveExts {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// OpenSource opens a directory on disk, or a zip or tar archive (optionally gzipped), as a Source
+//
+// If an archive contains nothing but a single directory, the Source is rooted inside of it. Zip
+// archives remain open for the lifetime of the program.
+func OpenSource(path string) (src Source, err error) {
+	if !IsArchive(path) {
+		if _, err = os.Stat(path); err != nil {
+			return
+		}
+		src = Source{Path: path}
+		return
+	}
+	var fsys fs.FS
+	if strings.HasSuffix(path, ".zip") {
+		fsys, err = zip.OpenReader(path)
+	} else {
+		fsys, err = readTar(path)
+	}
+	if err != nil {
+		return
+	}
+	src = NewSource(fsys, path)
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		src.Path = entries[0].Name()
+	}
+	return
+}
+
+// Join creates a Source for a subdirectory of this one
+func (s Source) Join(elem ...string) Source {
+	return Source{
+		FS:   s.FS,
+		Path: Join(s.FS, append([]string{s.Path}, elem...)...),
+		name: s.name,
+	}
+}
+
+// Dir reads the root of this Source, recursively
+func (s Source) Dir() (*Dir, error) {
+	return NewDirFS(s.FS, s.Path)
+}
+
+// Exists checks if the root of this Source exists
+func (s Source) Exists() bool {
+	_, err := Stat(s.FS, s.Path)
+	return err == nil
+}
+
+// ReadFile reads the contents of a file, relative to the root of this Source
+func (s Source) ReadFile(name string) ([]byte, error) {
+	return ReadFile(s.FS, Join(s.FS, s.Path, name))
+}
+
+// Sub provides the root of this Source as an fs.FS
+func (s Source) Sub() (fs.FS, error) {
+	if s.FS == nil {
+		return os.DirFS(s.Path), nil
+	}
+	return fs.Sub(s.FS, s.Path)
+}
+
+// String describes the location of this Source
+func (s Source) String() string {
+	if s.FS == nil {
+		return s.Path
+	}
+	if s.Path == "." {
+		return s.name
+	}
+	return s.name + ":" + s.Path
+}
@@ -0,0 +1,186 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package file
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeArchive creates a zip or tar archive, gzipped for ".tar.gz" and ".tgz", holding files by slash-separated name
+func writeArchive(t *testing.T, path string, files map[string]string) {
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	if strings.HasSuffix(path, ".zip") {
+		zw := zip.NewWriter(f)
+		for _, name := range names {
+			w, err := zw.Create(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			io.WriteString(w, files[name])
+		}
+		if err = zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	var w io.Writer = f
+	if strings.HasSuffix(path, ".gz") || strings.HasSuffix(path, ".tgz") {
+		gz := gzip.NewWriter(f)
+		defer gz.Close()
+		w = gz
+	}
+	tw := tar.NewWriter(w)
+	defer tw.Close()
+	for _, name := range names {
+		hdr := &tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(files[name])),
+			ModTime:  time.Unix(1600000000, 0),
+			Typeflag: tar.TypeReg,
+		}
+		if err = tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		io.WriteString(tw, files[name])
+	}
+}
+
+func TestOpenSourceArchives(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		root  string
+	}{
+		{"flat.zip", map[string]string{"templates/layout.html": "layout", "config/_site.yaml": "site"}, "."},
+		{"nested.zip", map[string]string{"theme/templates/layout.html": "layout", "theme/config/_site.yaml": "site"}, "theme"},
+		{"flat.tar", map[string]string{"templates/layout.html": "layout", "config/_site.yaml": "site"}, "."},
+		{"nested.tar.gz", map[string]string{"theme/templates/layout.html": "layout", "theme/config/_site.yaml": "site"}, "theme"},
+		{"nested.tgz", map[string]string{"/theme/templates/layout.html": "layout", "theme/config/_site.yaml": "site"}, "theme"},
+	}
+	for _, test := range tests {
+		path := filepath.Join(t.TempDir(), test.name)
+		writeArchive(t, path, test.files)
+		src, err := OpenSource(path)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+			continue
+		}
+		if src.Path != test.root {
+			t.Errorf("%s: root = %q, want %q", test.name, src.Path, test.root)
+		}
+		raw, err := src.ReadFile("templates/layout.html")
+		if err != nil || string(raw) != "layout" {
+			t.Errorf("%s: layout = %q, %v, want %q", test.name, raw, err, "layout")
+		}
+		dir, err := src.Join("config").Dir()
+		if err != nil {
+			t.Errorf("%s: unexpected error reading config: %s", test.name, err)
+			continue
+		}
+		if _, ok := dir.Files["_site.yaml"]; !ok || len(dir.Files) != 1 {
+			t.Errorf("%s: config files = %v, want only _site.yaml", test.name, dir.Files)
+		}
+		if src.Join("missing").Exists() {
+			t.Errorf("%s: missing directory should not exist", test.name)
+		}
+	}
+}
+
+func TestOpenSourceDir(t *testing.T) {
+	dir := t.TempDir()
+	src, err := OpenSource(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if src.FS != nil || src.Path != dir || src.String() != dir {
+		t.Errorf("source = %#v, want %q on disk", src, dir)
+	}
+	if _, err = OpenSource(filepath.Join(dir, "missing")); !os.IsNotExist(err) {
+		t.Errorf("error = %v, want not exist", err)
+	}
+	if _, err = OpenSource(filepath.Join(dir, "missing.zip")); err == nil {
+		t.Error("expected an error for a missing archive")
+	}
+}
+
+func TestMemFS(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.tar")
+	writeArchive(t, path, map[string]string{"a.txt": "a", "b.txt": "b", "c/d.txt": "d"})
+	fsys, err := readTar(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = fsys.Open("missing.txt"); !os.IsNotExist(err) {
+		t.Errorf("error = %v, want not exist", err)
+	}
+	if _, err = fsys.Open("../a.txt"); !os.IsNotExist(err) {
+		t.Errorf("error = %v, want not exist for an invalid path", err)
+	}
+	f, err := fsys.Open(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := f.(fs.ReadDirFile)
+	var names []string
+	for {
+		entries, err := dir.ReadDir(2)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) > 2 {
+			t.Errorf("found %d entries, want at most 2", len(entries))
+		}
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+	}
+	if got := strings.Join(names, " "); got != "a.txt b.txt c" {
+		t.Errorf("entries = %q, want %q", got, "a.txt b.txt c")
+	}
+	file, err := fsys.Open("a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = file.(fs.ReadDirFile).ReadDir(-1); err == nil {
+		t.Error("expected an error reading a file as a directory")
+	}
+	info, err := fs.Stat(fsys, "c")
+	if err != nil || !info.IsDir() {
+		t.Errorf("c = %v, %v, want an implied directory", info, err)
+	}
+}
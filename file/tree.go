@@ -54,9 +54,9 @@ func (t *Tree) Sub(name string) (sub *Tree, err error) {
 	return
 }
 
-// Merge combines two trees as if they had the same root
-func (t *Tree) Merge(other *Tree) (next *Tree, err error) {
-	root, err := t.Root.Merge(other.Root)
+// Merge combines two trees as if they had the same root, resolving collisions by a MergePolicy
+func (t *Tree) Merge(other *Tree, policy MergePolicy) (next *Tree, err error) {
+	root, err := t.Root.Merge(other.Root, policy)
 	if err != nil {
 		return
 	}
@@ -1,10 +1,13 @@
 module github.com/DataDrake/static-cling
 
-go 1.15
+go 1.16
 
 require (
+	github.com/BurntSushi/toml v1.3.2
 	github.com/DataDrake/cli-ng/v2 v2.0.2
 	github.com/DataDrake/waterlog v1.0.5
-	github.com/russross/blackfriday/v2 v2.1.0 // indirect
-	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+	github.com/alecthomas/chroma v0.10.0
+	github.com/russross/blackfriday/v2 v2.1.0
+	golang.org/x/net v0.0.0-20210614182718-04defd469f4e
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
 )
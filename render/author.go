@@ -0,0 +1,111 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package render
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DataDrake/static-cling/config"
+	"github.com/DataDrake/static-cling/content"
+	"github.com/DataDrake/static-cling/templates"
+	"html/template"
+	"sort"
+	"strings"
+)
+
+// AuthorDir is the directory of the build tree which contains the listing for each Author
+const AuthorDir = "authors"
+
+// Author is all of the data necessary to render the listing page for an Author
+type Author struct {
+	Context
+	Author   *config.Author
+	Page     *content.Page
+	Pages    content.Pages
+	layout   templates.Template
+	template templates.Template
+}
+
+// NewAuthor creates an Author listing, using the specified template if there is one
+func NewAuthor(site *Site, conf *config.Author, tmpl templates.Template) *Author {
+	author := &Author{
+		Context: NewContext(site.Config, nil),
+		Author:  conf,
+		Page: &content.Page{
+			Title:  conf.Name,
+			Author: conf.Key,
+		},
+		layout:   site.layout,
+		template: tmpl,
+	}
+	author.URL = "/" + AuthorDir + "/" + conf.Key + "/"
+	author.outputs = site.Outputs
+	return author
+}
+
+// Render generates the listing page and feed for this Author, from every Page of the Site
+func (a *Author) Render(pages content.Pages, dst *content.Dir) error {
+	sub, err := subDir(dst, a.Author.Key)
+	if err != nil {
+		return err
+	}
+	a.Pages = pages.Where("author", a.Author.Key).Latest()
+	a.Page.Date = a.Pages.Newest()
+	if a.template != nil {
+		out, err := a.applyTemplates()
+		if err != nil {
+			return err
+		}
+		if err = a.writeHTML(sub, "index.html", out, a.Page); err != nil {
+			return err
+		}
+	}
+	return NewFeed(a.Site, a.Author.Name, a.URL, a.Pages).Render(sub)
+}
+
+// applyTemplates generates HTML for this Author, using the specified templates
+func (a *Author) applyTemplates() (out string, err error) {
+	var content strings.Builder
+	if err = a.template.Execute(&content, a); err != nil {
+		return
+	}
+	a.Page.Content = template.HTML(content.String())
+	content.Reset()
+	if err = a.layout.Execute(&content, a); err != nil {
+		return
+	}
+	out = content.String()
+	return
+}
+
+// checkAuthors makes sure that every Page refers to a configured Author, reporting every Page which does not
+func checkAuthors(site *config.Site, pages content.Pages) error {
+	var unknown []string
+	for _, page := range pages {
+		if page.Author == "" {
+			continue
+		}
+		if _, ok := site.Authors[page.Author]; !ok {
+			unknown = append(unknown, fmt.Sprintf("page %q refers to unknown author %q", page.Path(), page.Author))
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return errors.New(strings.Join(unknown, "\n"))
+}
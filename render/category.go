@@ -20,15 +20,13 @@ import (
 	"github.com/DataDrake/static-cling/config"
 	"github.com/DataDrake/static-cling/content"
 	"github.com/DataDrake/static-cling/templates"
-	"path/filepath"
+	"html/template"
 	"strings"
-	"time"
 )
 
 // Category is all of the data necessary to render an Category index page
 type Category struct {
-	Site     *config.Site
-	Section  *config.Section
+	Context
 	Category *config.Category
 	Page     *content.Page
 	Pages    content.Pages
@@ -45,45 +43,43 @@ func NewCategory(section *Section, conf *config.Category) (category *Category, e
 	}
 	page := &content.Page{
 		Title:    conf.Name,
-		Date:     time.Now(),
 		Category: conf.Name,
 	}
 	category = &Category{
-		Site:     section.Site,
-		Section:  section.Config,
+		Context:  NewContext(section.Site, section.Config, conf.Vars),
 		Category: conf,
 		Page:     page,
 		name:     strings.ToLower(conf.Name),
 		layout:   section.layout,
 		template: template,
 	}
+	category.URL = "/" + section.key + "/" + category.name + "/"
+	category.outputs = section.outputs
 	return
 }
 
 // Render generates HTML for this Category, using the specified templates
 // TODO: figure out how to not do this all the time
 func (c *Category) Render(src, dst *content.Dir, force bool) (err error) {
-	sub, ok := dst.Subs[c.name]
-	if !ok {
-		if _, err = dst.Mkdir(c.name); err != nil {
-			return
-		}
-		if sub, err = content.NewDir(filepath.Join(dst.Path, c.name)); err != nil {
-			return err
-		}
-		dst.Subs[c.name] = sub
+	sub, err := subDir(dst, c.name)
+	if err != nil {
+		return
 	}
 	c.setPages(src)
+	c.Page.Date = c.Pages.Newest()
 	out, err := c.applyTemplates()
 	if err != nil {
 		return err
 	}
-	return writeHTML(sub, "index.html", out)
+	return c.writeHTML(sub, "index.html", out, c.Page)
 }
 
-// setPages recurses the source directory for any and all pages in this Category
+// setPages recurses the source directory for any and all pages in this Category, skipping nested Sections
 func (c *Category) setPages(src *content.Dir) {
 	for _, dir := range src.Subs {
+		if _, ok := c.Site.Sections[strings.Trim(dir.URL, "/")]; ok {
+			continue
+		}
 		c.setPages(dir)
 	}
 	for _, page := range src.Pages {
@@ -99,7 +95,7 @@ func (c *Category) applyTemplates() (out string, err error) {
 	if err = c.template.Execute(&content, c); err != nil {
 		return
 	}
-	c.Page.Content = content.String()
+	c.Page.Content = template.HTML(content.String())
 	content.Reset()
 	if err = c.layout.Execute(&content, c); err != nil {
 		return
@@ -0,0 +1,75 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package render
+
+import (
+	"github.com/DataDrake/static-cling/config"
+	"github.com/DataDrake/static-cling/content"
+)
+
+// Context is the data available to every template, regardless of what is being rendered
+type Context struct {
+	Site    *config.Site
+	Section *config.Section
+	Data    config.Data
+	Params  config.Params
+	URL     string
+	outputs *Outputs
+}
+
+// NewContext creates a Context for the specified Site and Section
+//
+// Params are merged from the Site and Section variables, followed by any additional
+// Variables in increasing order of precedence (see config.MergeParams). The variables of
+// a nested Section take precedence over those of its parents.
+func NewContext(site *config.Site, section *config.Section, vars ...config.Variables) Context {
+	var all []config.Variables
+	for parent := section; parent != nil; parent = parent.Parent {
+		all = append([]config.Variables{parent.Vars}, all...)
+	}
+	all = append([]config.Variables{site.Vars}, all...)
+	return Context{
+		Site:    site,
+		Section: section,
+		Data:    site.Data,
+		Params:  config.MergeParams(append(all, vars...)...),
+	}
+}
+
+// Sections provides every Section of the Site, ordered by weight and then by name
+func (c Context) Sections() []*config.Section {
+	return c.Site.Sections.List()
+}
+
+// Subsections provides the Sections nested directly inside of the current Section, or the top-level Sections outside of one
+func (c Context) Subsections() []*config.Section {
+	return c.Site.Sections.Children(c.Section)
+}
+
+// Menus resolves the navigation Menus of the Site for the URL being rendered
+func (c Context) Menus() Menus {
+	return NewMenus(c.Site, c.outputs, c.URL)
+}
+
+// writeHTML writes a rendered Page to the Destination directory, recording it in the Outputs of the Site
+func (c *Context) writeHTML(dst *content.Dir, name, out string, page *content.Page) error {
+	if err := writeHTML(dst, name, out); err != nil {
+		return err
+	}
+	c.outputs.add(dst, name, c.URL, page)
+	return nil
+}
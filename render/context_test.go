@@ -0,0 +1,53 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package render
+
+import (
+	"github.com/DataDrake/static-cling/config"
+	"testing"
+)
+
+func TestContextParams(t *testing.T) {
+	vars := func(title string) config.Variables {
+		return config.Variables{Values: map[string]interface{}{"title": title, title: true}}
+	}
+	site := &config.Site{Vars: vars("site")}
+	docs := &config.Section{Vars: vars("docs")}
+	api := &config.Section{Vars: vars("api"), Parent: docs}
+	tests := []struct {
+		name    string
+		section *config.Section
+		vars    []config.Variables
+		want    string
+	}{
+		{"site", nil, nil, "site"},
+		{"section", docs, nil, "docs"},
+		{"nested section", api, nil, "api"},
+		{"category", api, []config.Variables{vars("category")}, "category"},
+		{"page", api, []config.Variables{vars("category"), vars("page")}, "page"},
+	}
+	for _, test := range tests {
+		params := NewContext(site, test.section, test.vars...).Params
+		if params["title"] != test.want {
+			t.Errorf("%s: title = %v, want %q", test.name, params["title"], test.want)
+		}
+		// every level is still merged in, even when overridden
+		if params["site"] != true {
+			t.Errorf("%s: missing the site variables: %v", test.name, params)
+		}
+	}
+}
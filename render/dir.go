@@ -21,6 +21,7 @@ import (
 	"github.com/DataDrake/static-cling/config"
 	"github.com/DataDrake/static-cling/content"
 	"github.com/DataDrake/static-cling/templates"
+	"strings"
 )
 
 var (
@@ -34,10 +35,13 @@ type Dir struct {
 	Section  *config.Section
 	Pages    content.Pages
 	name     string
+	url      string
 	listings []string
 	layout   templates.Template
 	content  templates.Template
 	tmpls    *templates.Dir
+	outputs  *Outputs
+	root     *Site
 	section  bool
 }
 
@@ -51,10 +55,13 @@ func NewSectionDir(section *Section) (dir *Dir, err error) {
 		Site:     section.Site,
 		Section:  section.Config,
 		name:     section.name,
+		url:      "/" + section.key + "/",
 		listings: section.Config.Templates.Listings,
 		layout:   section.layout,
 		content:  content,
 		tmpls:    section.tmpls,
+		outputs:  section.outputs,
+		root:     section.root,
 		section:  true,
 	}
 	return
@@ -70,16 +77,22 @@ func (d *Dir) Sub(name string) *Dir {
 		Site:     d.Site,
 		Section:  d.Section,
 		name:     name,
+		url:      d.url + name + "/",
 		listings: listings,
 		layout:   d.layout,
 		content:  d.content,
 		tmpls:    d.tmpls,
+		outputs:  d.outputs,
+		root:     d.root,
 	}
 }
 
 // Render updates the contents of a destination directory from a source directory, for a given Dir config
 func (d *Dir) Render(src, dst *content.Dir, force bool) error {
 	for name := range dst.Dirs {
+		if d.section && d.Section.HasCategory(name) {
+			continue
+		}
 		if _, ok := src.Dirs[name]; ok {
 			continue
 		}
@@ -123,11 +136,25 @@ func (d *Dir) renderPages(src, dst *content.Dir, force bool) error {
 	return nil
 }
 
-// renderDirs update each subdirectory in this directory
+// renderDirs update each subdirectory in this directory, rendering nested Sections with their own configuration
 func (d *Dir) renderDirs(src, dst *content.Dir, force bool) error {
 	for name, dir := range src.Subs {
+		key := strings.Trim(d.url+name, "/")
+		if conf, ok := d.Site.Sections[key]; ok {
+			section, err := NewSection(d.root, key, conf)
+			if err != nil {
+				return err
+			}
+			if err = section.Render(src, dst, force); err != nil {
+				return err
+			}
+			continue
+		}
 		sub := d.Sub(name)
-		dstSub := dst.Subs[name]
+		dstSub, err := subDir(dst, name)
+		if err != nil {
+			return err
+		}
 		if err := sub.Render(dir, dstSub, force); err != nil {
 			return err
 		}
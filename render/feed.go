@@ -0,0 +1,93 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package render
+
+import (
+	"encoding/xml"
+	"github.com/DataDrake/static-cling/config"
+	"github.com/DataDrake/static-cling/content"
+	"github.com/DataDrake/static-cling/templates"
+	"time"
+)
+
+// FeedName is the name of the RSS feed generated alongside a listing
+const FeedName = "index.xml"
+
+// dublinCore is the XML namespace of the Dublin Core elements, used for the creator of each FeedItem
+const dublinCore = "http://purl.org/dc/elements/1.1/"
+
+// Feed is an RSS 2.0 document
+type Feed struct {
+	XMLName xml.Name    `xml:"rss"`
+	Version string      `xml:"version,attr"`
+	DC      string      `xml:"xmlns:dc,attr"`
+	Channel FeedChannel `xml:"channel"`
+}
+
+// FeedChannel describes the listing that a Feed was generated from
+type FeedChannel struct {
+	Title       string     `xml:"title"`
+	Link        string     `xml:"link"`
+	Description string     `xml:"description"`
+	Items       []FeedItem `xml:"item"`
+}
+
+// FeedItem is a single Page in a Feed
+type FeedItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	PubDate     string `xml:"pubDate,omitempty"`
+	Creator     string `xml:"dc:creator,omitempty"`
+	Description string `xml:"description"`
+}
+
+// NewFeed creates a Feed for a listing of Pages, from newest to oldest
+func NewFeed(site *config.Site, title, url string, pages content.Pages) *Feed {
+	base := templates.NewBaseURL(site.URL)
+	feed := &Feed{
+		Version: "2.0",
+		DC:      dublinCore,
+		Channel: FeedChannel{
+			Title:       title,
+			Link:        base.Abs(url),
+			Description: title + " | " + site.Name,
+		},
+	}
+	for _, page := range pages.Latest() {
+		item := FeedItem{
+			Title:       page.Title,
+			Link:        base.Abs(page.URL),
+			GUID:        base.Abs(page.URL),
+			Description: string(page.Summary),
+		}
+		if !page.Date.IsZero() {
+			item.PubDate = page.Date.Format(time.RFC1123Z)
+		}
+		// RSS requires an email address for <author>, so the name goes in <dc:creator> instead
+		if author, ok := site.Authors[page.Author]; ok {
+			item.Creator = author.Name
+		}
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+	return feed
+}
+
+// Render writes this Feed to the Destination directory
+func (f *Feed) Render(dst *content.Dir) error {
+	return writeXML(dst, FeedName, f)
+}
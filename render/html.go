@@ -17,6 +17,7 @@
 package render
 
 import (
+	"encoding/xml"
 	"github.com/DataDrake/static-cling/content"
 	"os"
 	"path/filepath"
@@ -24,6 +25,11 @@ import (
 
 // writeHTML creates a file in the Destination directory and writes the contents as HTML
 func writeHTML(dst *content.Dir, name, content string) error {
+	return writeFile(dst, name, content)
+}
+
+// writeFile creates a file in the Destination directory and writes the contents
+func writeFile(dst *content.Dir, name, content string) error {
 	out, err := os.OpenFile(filepath.Join(dst.Path, name), os.O_RDWR|os.O_TRUNC|os.O_CREATE, 0644)
 	if err != nil {
 		return err
@@ -32,3 +38,29 @@ func writeHTML(dst *content.Dir, name, content string) error {
 	_, err = out.Write([]byte(content))
 	return err
 }
+
+// writeXML creates a file in the Destination directory and writes a value as indented XML
+func writeXML(dst *content.Dir, name string, v interface{}) error {
+	out, err := xml.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return err
+	}
+	return writeFile(dst, name, xml.Header+string(out))
+}
+
+// subDir retrieves an immediate subdirectory of the Destination directory, creating it if missing
+func subDir(dst *content.Dir, name string) (sub *content.Dir, err error) {
+	if sub, ok := dst.Subs[name]; ok {
+		return sub, nil
+	}
+	if _, ok := dst.Dirs[name]; !ok {
+		if _, err = dst.Mkdir(name); err != nil {
+			return
+		}
+	}
+	if sub, err = content.NewDir(filepath.Join(dst.Path, name)); err != nil {
+		return
+	}
+	dst.Subs[name] = sub
+	return
+}
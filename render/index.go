@@ -17,17 +17,15 @@
 package render
 
 import (
-	"github.com/DataDrake/static-cling/config"
 	"github.com/DataDrake/static-cling/content"
 	"github.com/DataDrake/static-cling/templates"
+	"html/template"
 	"strings"
-	"time"
 )
 
 // Index is all of the data necessary to render an index page
 type Index struct {
-	Site     *config.Site
-	Section  *config.Section
+	Context
 	Page     *content.Page
 	Pages    content.Pages
 	layout   templates.Template
@@ -46,16 +44,17 @@ func NewIndex(d *Dir) (index *Index, err error) {
 	}
 	page := &content.Page{
 		Title: d.name,
-		Date:  time.Now(),
+		Date:  d.Pages.Newest(),
 	}
 	index = &Index{
-		Site:     d.Site,
-		Section:  d.Section,
+		Context:  NewContext(d.Site, d.Section),
 		Page:     page,
 		Pages:    d.Pages,
 		layout:   d.layout,
 		template: tmpl,
 	}
+	index.URL = d.url
+	index.outputs = d.outputs
 	return
 }
 
@@ -65,7 +64,7 @@ func (i *Index) Render(src, dst *content.Dir, force bool) error {
 	if err != nil {
 		return err
 	}
-	return writeHTML(dst, "index.html", out)
+	return i.writeHTML(dst, "index.html", out, i.Page)
 }
 
 // applyTemplates generates HTML for this Index, using the specified templates
@@ -74,7 +73,7 @@ func (i *Index) applyTemplates() (out string, err error) {
 	if err = i.template.Execute(&content, i); err != nil {
 		return
 	}
-	i.Page.Content = content.String()
+	i.Page.Content = template.HTML(content.String())
 	content.Reset()
 	if err = i.layout.Execute(&content, i); err != nil {
 		return
@@ -0,0 +1,57 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package render
+
+import (
+	"github.com/DataDrake/static-cling/config"
+	"github.com/DataDrake/static-cling/content"
+	"github.com/DataDrake/static-cling/templates"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIndexDate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "list.html"), []byte("list"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tmpls, err := templates.NewDir(nil, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recent := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	d := &Dir{
+		Site: &config.Site{},
+		Pages: content.Pages{
+			{Date: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{Date: recent},
+		},
+		url:      "/docs/",
+		listings: []string{"list"},
+		tmpls:    tmpls,
+		outputs:  NewOutputs(),
+	}
+	index, err := NewIndex(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !index.Page.Date.Equal(recent) {
+		t.Errorf("index date = %s, want the newest page date %s", index.Page.Date, recent)
+	}
+}
@@ -0,0 +1,216 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package render
+
+import (
+	"fmt"
+	"github.com/DataDrake/static-cling/config"
+	"golang.org/x/net/html"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// BrokenLink is an internal link which does not match any file or anchor in the build tree
+//
+// File is always the rendered file in the build tree that contains the link.
+type BrokenLink struct {
+	File   string
+	Link   string
+	Reason string
+}
+
+// String describes this BrokenLink
+func (b BrokenLink) String() string {
+	return fmt.Sprintf("%s: broken link %q, %s", b.File, b.Link, b.Reason)
+}
+
+// LinkChecker validates the internal links of every Output against the build tree and assets
+type LinkChecker struct {
+	site   *config.Site
+	build  string
+	assets string
+	ids    map[string]map[string]bool
+}
+
+// NewLinkChecker creates a LinkChecker for a build directory and its asset directory
+func NewLinkChecker(site *config.Site, build, assets string) *LinkChecker {
+	return &LinkChecker{
+		site:   site,
+		build:  build,
+		assets: assets,
+		ids:    make(map[string]map[string]bool),
+	}
+}
+
+// Check parses every Output and reports each internal link which is broken
+func (lc *LinkChecker) Check(outputs *Outputs) (broken []BrokenLink, err error) {
+	for _, out := range outputs.List() {
+		links, err := readLinks(out.Path)
+		if err != nil {
+			return nil, err
+		}
+		for _, link := range links {
+			if reason := lc.checkLink(out.URL, link); reason != "" {
+				broken = append(broken, BrokenLink{
+					File:   out.Path,
+					Link:   link,
+					Reason: reason,
+				})
+			}
+		}
+	}
+	return
+}
+
+// checkLink resolves a single link from a page URL, returning the reason that it is broken, if it is
+func (lc *LinkChecker) checkLink(page, link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "failed to parse URL"
+	}
+	if !lc.isInternal(u) {
+		return ""
+	}
+	target := u.Path
+	switch {
+	case target == "":
+		target = page
+	case !strings.HasPrefix(target, "/"):
+		target = path.Join(page[:strings.LastIndex(page, "/")+1], target)
+		if strings.HasSuffix(u.Path, "/") {
+			target += "/"
+		}
+	default:
+		target = lc.trimBase(target)
+	}
+	file, ok := lc.resolve(target)
+	if !ok {
+		return "target does not exist"
+	}
+	if u.Fragment == "" || !strings.HasSuffix(file, ".html") {
+		return ""
+	}
+	ids, err := lc.readIDs(file)
+	if err != nil {
+		return err.Error()
+	}
+	if !ids[u.Fragment] {
+		return fmt.Sprintf("anchor %q does not exist", u.Fragment)
+	}
+	return ""
+}
+
+// isInternal checks if a link points at this Site
+func (lc *LinkChecker) isInternal(u *url.URL) bool {
+	if u.Scheme == "" && u.Host == "" {
+		return u.Opaque == ""
+	}
+	base, err := url.Parse(lc.site.URL)
+	if err != nil || base.Host == "" {
+		return false
+	}
+	return u.Host == base.Host && (u.Scheme == "" || u.Scheme == base.Scheme)
+}
+
+// trimBase removes the path of the Site URL from an absolute path, if present
+//
+// Only whole path segments are removed, so "/blogroll/" is left alone for a Site at "/blog".
+func (lc *LinkChecker) trimBase(target string) string {
+	base, err := url.Parse(lc.site.URL)
+	if err != nil {
+		return target
+	}
+	prefix := strings.TrimSuffix(base.Path, "/")
+	if prefix == "" || (target != prefix && !strings.HasPrefix(target, prefix+"/")) {
+		return target
+	}
+	return "/" + strings.TrimPrefix(strings.TrimPrefix(target, prefix), "/")
+}
+
+// resolve finds the file for a site path in the build tree or assets, trying "index.html" for directories
+func (lc *LinkChecker) resolve(target string) (file string, ok bool) {
+	rel := filepath.FromSlash(strings.TrimPrefix(target, "/"))
+	for _, root := range []string{lc.build, lc.assets} {
+		file = filepath.Join(root, rel)
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			return file, true
+		}
+		file = filepath.Join(file, "index.html")
+		if _, err = os.Stat(file); err == nil {
+			return file, true
+		}
+	}
+	return "", false
+}
+
+// readIDs gets every anchor in an HTML file, caching the result
+func (lc *LinkChecker) readIDs(file string) (ids map[string]bool, err error) {
+	if ids, ok := lc.ids[file]; ok {
+		return ids, nil
+	}
+	ids = make(map[string]bool)
+	err = walkTags(file, func(tok html.Token) {
+		for _, attr := range tok.Attr {
+			if attr.Key == "id" || (attr.Key == "name" && tok.Data == "a") {
+				ids[attr.Val] = true
+			}
+		}
+	})
+	lc.ids[file] = ids
+	return
+}
+
+// readLinks gets the target of every "href" and "src" in an HTML file
+func readLinks(file string) (links []string, err error) {
+	err = walkTags(file, func(tok html.Token) {
+		for _, attr := range tok.Attr {
+			if attr.Key == "href" || attr.Key == "src" {
+				links = append(links, attr.Val)
+			}
+		}
+	})
+	return
+}
+
+// walkTags calls a function for the start tag of every element in an HTML file
+func walkTags(file string, fn func(tok html.Token)) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	z := html.NewTokenizer(f)
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if err := z.Err(); err != io.EOF {
+				return err
+			}
+			return nil
+		case html.StartTagToken, html.SelfClosingTagToken:
+			fn(z.Token())
+		}
+	}
+}
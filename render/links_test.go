@@ -0,0 +1,72 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package render
+
+import (
+	"github.com/DataDrake/static-cling/config"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLinkCheckerPaths(t *testing.T) {
+	build := t.TempDir()
+	pages := map[string]string{
+		"index.html":       `<a href="/missing/">Missing</a><a href="/about/#team">Team</a><a href="/blog/about/">About</a>`,
+		"about/index.html": `<h2 id="team">Team</h2><a href="#history">History</a>`,
+		// shares a prefix with the path of the site URL, but is not beneath it
+		"blogroll/a.html": `<a href="/blogroll/a.html">Self</a><a href="/blogroll/b.html">Missing</a>`,
+	}
+	outputs := NewOutputs()
+	for name, body := range pages {
+		path := filepath.Join(build, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		url := "/" + filepath.ToSlash(filepath.Dir(name)) + "/"
+		if url == "/./" {
+			url = "/"
+		}
+		// only one of the outputs comes from a content file
+		out := &Output{URL: url, Path: path}
+		if name == "index.html" {
+			out.Source = "content/index.html"
+		}
+		outputs.byPath[path] = out
+	}
+	site := &config.Site{URL: "https://example.com/blog"}
+	broken, err := NewLinkChecker(site, build, filepath.Join(build, "assets")).Check(outputs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]string{
+		"/missing/":        filepath.Join(build, "index.html"),
+		"#history":         filepath.Join(build, "about", "index.html"),
+		"/blogroll/b.html": filepath.Join(build, "blogroll", "a.html"),
+	}
+	if len(broken) != len(want) {
+		t.Fatalf("found %d broken links, want %d: %v", len(broken), len(want), broken)
+	}
+	for _, link := range broken {
+		if link.File != want[link.Link] {
+			t.Errorf("broken link %q reported in %q, want %q", link.Link, link.File, want[link.Link])
+		}
+	}
+}
@@ -0,0 +1,140 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package render
+
+import (
+	"github.com/DataDrake/static-cling/config"
+	"path"
+	"sort"
+	"strings"
+)
+
+// MenuItem is a MenuEntry which has been resolved for the page being rendered
+type MenuItem struct {
+	Name     string
+	URL      string
+	Icon     string
+	Weight   int
+	Active   bool
+	Ancestor bool
+	Children Menu
+}
+
+// Menu is an ordered list of MenuItems
+type Menu []*MenuItem
+
+// Menus is a map of named Menus, ready for use in templates
+type Menus map[string]Menu
+
+// NewMenus resolves the configured Menus for a Site, marking the entries for the current URL as Active
+//
+// Sections which register themselves in a Menu are added alongside any configured entries, using
+// the weight of the Section unless the MenuRef specifies its own. Nested Sections become children
+// of their parent, when both register in the same Menu.
+//
+// Entries for a Page link to the URL it was given when the content was loaded, when it can be found in outputs.
+func NewMenus(site *config.Site, outputs *Outputs, current string) Menus {
+	menus := make(Menus)
+	for name, entries := range site.Menus {
+		menus[name] = newMenu(site, outputs, entries, current)
+	}
+	items := make(map[*config.Section]*MenuItem)
+	for _, section := range site.Sections.List() {
+		if section.Menu == nil {
+			continue
+		}
+		entry := &config.MenuEntry{
+			Name:    section.Name,
+			Section: section.Key,
+			Weight:  section.Menu.Weight,
+			Icon:    section.Menu.Icon,
+		}
+		if entry.Weight == 0 {
+			entry.Weight = section.Weight
+		}
+		items[section] = newMenuItem(site, outputs, entry, current)
+	}
+	for _, section := range site.Sections.List() {
+		item, ok := items[section]
+		if !ok {
+			continue
+		}
+		if parent, ok := items[section.Parent]; ok && section.Parent.Menu.Name == section.Menu.Name {
+			parent.Children = append(parent.Children, item)
+			continue
+		}
+		menus[section.Menu.Name] = append(menus[section.Menu.Name], item)
+	}
+	for _, item := range items {
+		item.Children.sort()
+	}
+	for _, menu := range menus {
+		menu.sort()
+	}
+	return menus
+}
+
+// newMenu resolves a list of MenuEntries
+func newMenu(site *config.Site, outputs *Outputs, entries config.Menu, current string) (menu Menu) {
+	for _, entry := range entries {
+		menu = append(menu, newMenuItem(site, outputs, entry, current))
+	}
+	menu.sort()
+	return
+}
+
+// newMenuItem resolves a single MenuEntry, including its children
+func newMenuItem(site *config.Site, outputs *Outputs, entry *config.MenuEntry, current string) *MenuItem {
+	item := &MenuItem{
+		Name:     entry.Name,
+		URL:      entry.URL,
+		Icon:     entry.Icon,
+		Weight:   entry.Weight,
+		Children: newMenu(site, outputs, entry.Children, current),
+	}
+	switch {
+	case entry.Section != "":
+		item.URL = "/" + entry.Section + "/"
+		if section, ok := site.Sections[entry.Section]; ok && item.Name == "" {
+			item.Name = section.Name
+		}
+		item.Ancestor = strings.HasPrefix(current, item.URL) && current != item.URL
+	case entry.Page != "":
+		if url, ok := outputs.pageURL(entry.Page); ok {
+			item.URL = url
+			break
+		}
+		item.URL = path.Join("/", entry.Page)
+		if path.Ext(item.URL) == "" {
+			item.URL += ".html"
+		}
+	}
+	item.Active = current != "" && current == item.URL
+	for _, child := range item.Children {
+		if child.Active || child.Ancestor {
+			item.Ancestor = true
+		}
+	}
+	return item
+}
+
+// sort orders a Menu by weight, keeping the configured order for equal weights
+func (m Menu) sort() {
+	sort.SliceStable(m, func(i, j int) bool {
+		return m[i].Weight < m[j].Weight
+	})
+}
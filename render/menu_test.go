@@ -0,0 +1,85 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package render
+
+import (
+	"github.com/DataDrake/static-cling/config"
+	"testing"
+)
+
+func TestMenuPages(t *testing.T) {
+	site := &config.Site{
+		Menus: config.Menus{
+			"main": config.Menu{
+				{Name: "Home", Page: "index", Weight: 1},
+				{Name: "Docs", Page: "docs/index", Weight: 2},
+				{Name: "Docs Dir", Page: "docs/", Weight: 3},
+				{Name: "Intro", Page: "docs/intro.html", Weight: 4},
+				{Name: "Missing", Page: "missing", Weight: 5},
+			},
+		},
+	}
+	outputs := NewOutputs()
+	outputs.pages["index"] = "/"
+	outputs.pages["docs/index"] = "/docs/"
+	outputs.pages["docs/intro"] = "/docs/intro.html"
+	tests := []struct {
+		current string
+		urls    []string
+		active  []bool
+	}{
+		{"/docs/", []string{"/", "/docs/", "/docs/", "/docs/intro.html", "/missing.html"}, []bool{false, true, true, false, false}},
+		{"/", []string{"/", "/docs/", "/docs/", "/docs/intro.html", "/missing.html"}, []bool{true, false, false, false, false}},
+	}
+	for _, test := range tests {
+		menu := NewMenus(site, outputs, test.current)["main"]
+		if len(menu) != len(test.urls) {
+			t.Fatalf("menu has %d items, want %d", len(menu), len(test.urls))
+		}
+		for i, item := range menu {
+			if item.URL != test.urls[i] || item.Active != test.active[i] {
+				t.Errorf("at %q, %s = (%q, active: %t), want (%q, active: %t)", test.current, item.Name, item.URL, item.Active, test.urls[i], test.active[i])
+			}
+		}
+	}
+}
+
+func TestMenuSectionWeights(t *testing.T) {
+	site := &config.Site{
+		Menus: config.Menus{
+			"main": config.Menu{
+				{Name: "Home", Page: "index", Weight: 15},
+			},
+		},
+		Sections: config.Sections{
+			"docs":  {Key: "docs", Name: "Docs", Weight: 20, Menu: &config.MenuRef{Name: "main"}},
+			"blog":  {Key: "blog", Name: "Blog", Weight: 10, Menu: &config.MenuRef{Name: "main", Weight: 30}},
+			"news":  {Key: "news", Name: "News", Weight: 5, Menu: &config.MenuRef{Name: "main"}},
+			"draft": {Key: "draft", Name: "Draft", Weight: 1},
+		},
+	}
+	menu := NewMenus(site, NewOutputs(), "/")["main"]
+	want := []string{"News", "Home", "Docs", "Blog"}
+	if len(menu) != len(want) {
+		t.Fatalf("menu has %d items, want %d", len(menu), len(want))
+	}
+	for i, item := range menu {
+		if item.Name != want[i] {
+			t.Errorf("item %d = %s, want %s", i, item.Name, want[i])
+		}
+	}
+}
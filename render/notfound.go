@@ -0,0 +1,77 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package render
+
+import (
+	"github.com/DataDrake/static-cling/content"
+	"github.com/DataDrake/static-cling/templates"
+	"html/template"
+	"strings"
+)
+
+// NotFoundName is the name of the page served for missing files
+const NotFoundName = "404.html"
+
+// NotFound is all of the data necessary to render the page for missing files
+type NotFound struct {
+	Context
+	Page     *content.Page
+	layout   templates.Template
+	template templates.Template
+}
+
+// NewNotFound creates a NotFound page, using the specified template
+func NewNotFound(site *Site, tmpl templates.Template) *NotFound {
+	nf := &NotFound{
+		Context: NewContext(site.Config, nil),
+		Page: &content.Page{
+			Title: "Not Found",
+			Sitemap: content.Sitemap{
+				Exclude: true,
+			},
+		},
+		layout:   site.layout,
+		template: tmpl,
+	}
+	nf.URL = "/" + NotFoundName
+	nf.outputs = site.Outputs
+	return nf
+}
+
+// Render generates the NotFound page in the root of the build tree
+func (nf *NotFound) Render(dst *content.Dir) error {
+	out, err := nf.applyTemplates()
+	if err != nil {
+		return err
+	}
+	return nf.writeHTML(dst, NotFoundName, out, nf.Page)
+}
+
+// applyTemplates generates HTML for this NotFound page, using the specified templates
+func (nf *NotFound) applyTemplates() (out string, err error) {
+	var content strings.Builder
+	if err = nf.template.Execute(&content, nf); err != nil {
+		return
+	}
+	nf.Page.Content = template.HTML(content.String())
+	content.Reset()
+	if err = nf.layout.Execute(&content, nf); err != nil {
+		return
+	}
+	out = content.String()
+	return
+}
@@ -0,0 +1,106 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package render
+
+import (
+	"github.com/DataDrake/static-cling/content"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Output is a single HTML file written to the build tree
+type Output struct {
+	URL      string
+	Path     string
+	Source   string
+	Modified time.Time
+	Sitemap  content.Sitemap
+}
+
+// Outputs records every HTML file written while rendering a Site, and the URL of every Page in its content
+type Outputs struct {
+	byPath map[string]*Output
+	pages  map[string]string
+}
+
+// NewOutputs creates an empty list of Outputs
+func NewOutputs() *Outputs {
+	return &Outputs{
+		byPath: make(map[string]*Output),
+		pages:  make(map[string]string),
+	}
+}
+
+// addPages records the URL of every Page in a content tree, keyed by its slash-separated path without an extension
+func (o *Outputs) addPages(root *content.Dir) {
+	for _, page := range root.All() {
+		rel, err := filepath.Rel(root.Path, page.Path())
+		if err != nil {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		o.pages[strings.TrimSuffix(rel, path.Ext(rel))] = page.URL
+	}
+}
+
+// pageURL finds the URL of a Page by its path within the content tree, with or without an extension
+//
+// A path to a directory, such as "docs/", refers to its index Page.
+func (o *Outputs) pageURL(name string) (url string, ok bool) {
+	if o == nil {
+		return
+	}
+	key := strings.Trim(name, "/")
+	key = strings.TrimSuffix(key, path.Ext(key))
+	if url, ok = o.pages[key]; ok {
+		return
+	}
+	url, ok = o.pages[path.Join(key, "index")]
+	return
+}
+
+// add records an Output, replacing any earlier Output to the same file
+func (o *Outputs) add(dst *content.Dir, name, url string, page *content.Page) {
+	out := &Output{
+		URL:      url,
+		Path:     filepath.Join(dst.Path, name),
+		Source:   page.Path(),
+		Modified: page.Date,
+		Sitemap:  page.Sitemap,
+	}
+	if out.Modified.IsZero() {
+		out.Modified = page.Modified()
+	}
+	o.byPath[out.Path] = out
+}
+
+// List provides every Output, ordered by URL
+func (o *Outputs) List() (list []*Output) {
+	for _, out := range o.byPath {
+		list = append(list, out)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].URL != list[j].URL {
+			return list[i].URL < list[j].URL
+		}
+		return list[i].Path < list[j].Path
+	})
+	return
+}
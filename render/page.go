@@ -20,14 +20,15 @@ import (
 	"github.com/DataDrake/static-cling/config"
 	"github.com/DataDrake/static-cling/content"
 	"github.com/DataDrake/static-cling/templates"
+	"html/template"
 	"strings"
 )
 
 // Page contains all of the data necessary to render a single content Page
 type Page struct {
-	Site     *config.Site
-	Section  *config.Section
+	Context
 	Page     *content.Page
+	Author   *config.Author
 	output   string
 	layout   templates.Template
 	template templates.Template
@@ -39,14 +40,23 @@ func NewPage(d *Dir, p *content.Page) (page *Page, err error) {
 	if err != nil {
 		return
 	}
+	var vars []config.Variables
+	if d.Section != nil {
+		if category := d.Section.Category(p.Category); category != nil {
+			vars = append(vars, category.Vars)
+		}
+	}
+	vars = append(vars, p.Vars)
 	page = &Page{
-		Site:     d.Site,
-		Section:  d.Section,
+		Context:  NewContext(d.Site, d.Section, vars...),
 		Page:     p,
+		Author:   d.Site.Authors[p.Author],
 		output:   name,
 		layout:   d.layout,
 		template: d.content,
 	}
+	page.URL = p.URL
+	page.outputs = d.outputs
 	return
 }
 
@@ -56,18 +66,22 @@ func (p *Page) Render(src, dst *content.Dir, force bool) error {
 	if err != nil {
 		return err
 	}
-	return writeHTML(dst, p.output, out)
+	return p.writeHTML(dst, p.output, out, p.Page)
 }
 
 // applyTemplates evaluates the page template and then uses the output as the content for the layout template
+//
+// The layout is given a copy of the Page, so that the original content is still available to other templates.
 func (p *Page) applyTemplates() (out string, err error) {
 	var content strings.Builder
 	if err = p.template.Execute(&content, p); err != nil {
 		return
 	}
-	p.Page.Content = content.String()
+	page, inner := *p, *p.Page
+	inner.Content = template.HTML(content.String())
+	page.Page = &inner
 	content.Reset()
-	if err = p.layout.Execute(&content, p); err != nil {
+	if err = p.layout.Execute(&content, &page); err != nil {
 		return
 	}
 	out = content.String()
@@ -20,47 +20,48 @@ import (
 	"github.com/DataDrake/static-cling/config"
 	"github.com/DataDrake/static-cling/content"
 	"github.com/DataDrake/static-cling/templates"
-	"path/filepath"
+	"path"
 )
 
 // Section contains all of the data necessary to configure rendering for a section
 type Section struct {
-	Site   *config.Site
-	Config *config.Section
-	name   string
-	layout templates.Template
-	tmpls  *templates.Dir
+	Site    *config.Site
+	Config  *config.Section
+	key     string
+	name    string
+	root    *Site
+	layout  templates.Template
+	tmpls   *templates.Dir
+	outputs *Outputs
 }
 
-// NewSection creates a new Section
-func NewSection(site *Site, name string, conf *config.Section) (section *Section, err error) {
-	tmpls, err := site.tmpls.Sub(name)
+// NewSection creates a new Section, where the key is its path in the content tree (eg. "docs/api")
+func NewSection(site *Site, key string, conf *config.Section) (section *Section, err error) {
+	tmpls, err := site.tmpls.Section(key)
 	if err != nil {
 		return
 	}
 	section = &Section{
-		Site:   site.Config,
-		Config: conf,
-		name:   name,
-		layout: site.layout,
-		tmpls:  tmpls,
+		Site:    site.Config,
+		Config:  conf,
+		key:     key,
+		name:    path.Base(key),
+		root:    site,
+		layout:  site.layout,
+		tmpls:   tmpls,
+		outputs: site.Outputs,
 	}
 	return
 }
 
 // Render updates the contents of a destination tree from a source tree, for a given Section
+//
+// The source and destination are the directories containing the Section, not the Section itself.
 func (s *Section) Render(src, dst *content.Dir, force bool) (err error) {
 	srcDir := src.Subs[s.name]
-	dstDir, ok := dst.Subs[s.name]
-	if !ok {
-		if _, err = dstDir.Mkdir(s.name); err != nil {
-			return err
-		}
-		sub, err := content.NewDir(filepath.Join(dstDir.Path, s.name))
-		if err != nil {
-			return err
-		}
-		dstDir.Subs[s.name] = sub
+	dstDir, err := subDir(dst, s.name)
+	if err != nil {
+		return
 	}
 	for name := range dstDir.Dirs {
 		if s.Config.HasCategory(name) {
@@ -25,9 +25,11 @@ import (
 
 // Site is the content of the Site we are rendering
 type Site struct {
-	Config *config.Site
-	layout templates.Template
-	tmpls  *templates.Tree
+	Config  *config.Site
+	Outputs *Outputs
+	base    templates.BaseURL
+	layout  templates.Template
+	tmpls   *templates.Tree
 }
 
 // NewSite creates a Site from a configuration and template tree
@@ -38,6 +40,7 @@ func NewSite(conf *config.Site, tmpls *templates.Tree) (site *Site, err error) {
 	}
 	site = &Site{
 		Config: conf,
+		base:   templates.NewBaseURL(conf.URL),
 		layout: layout,
 		tmpls:  tmpls,
 	}
@@ -46,31 +49,56 @@ func NewSite(conf *config.Site, tmpls *templates.Tree) (site *Site, err error) {
 
 // Render each of the sections and the root pages of the site
 func (s *Site) Render(src, dst *content.Tree, force bool) error {
+	s.Outputs = NewOutputs()
+	s.Outputs.addPages(src.Root)
+	if err := checkAuthors(s.Config, src.Root.All()); err != nil {
+		return err
+	}
 	if err := s.sections(src, dst, force); err != nil {
 		return err
 	}
-	return s.index(src.Root, dst.Root, force)
+	if err := s.authors(src, dst); err != nil {
+		return err
+	}
+	if err := s.index(src.Root, dst.Root, force); err != nil {
+		return err
+	}
+	if err := s.notFound(dst.Root); err != nil {
+		return err
+	}
+	if err := s.sitemap(dst.Root); err != nil {
+		return err
+	}
+	return s.robots(dst.Root)
 }
 
 // sections updates each section of the site as needed
 func (s *Site) sections(src *content.Tree, dst *content.Tree, force bool) error {
 	log.Infoln("Checking for sections that no longer exist")
 	for name := range dst.Root.Dirs {
+		if name == AuthorDir {
+			// generated, rather than a section
+			continue
+		}
 		if _, ok := src.Root.Dirs[name]; !ok {
 			if err := dst.Root.RemoveAll(name); err != nil {
-				return nil
+				return err
 			}
+			delete(dst.Root.Subs, name)
 		}
 	}
 	log.Goodln("DONE")
 	log.Infoln("Updating sections")
 	for name := range src.Root.Dirs {
-		config, ok := s.Config.Sections[name]
-		if !ok {
-			log.Warnf("Missing config for section %q, skipping", name)
+		if _, ok := s.Config.Sections[name]; !ok {
+			log.Warnf("Missing config for section %q, skipping\n", name)
+		}
+	}
+	for _, config := range s.Config.Sections.Children(nil) {
+		if _, ok := src.Root.Dirs[config.Key]; !ok {
 			continue
 		}
-		section, err := NewSection(s, name, config)
+		section, err := NewSection(s, config.Key, config)
 		if err != nil {
 			return err
 		}
@@ -82,6 +110,71 @@ func (s *Site) sections(src *content.Tree, dst *content.Tree, force bool) error
 	return nil
 }
 
+// RootTemplate is the template used for the Pages in the root of the content tree, such as the home page
+const RootTemplate = "page"
+
+// index generates the Pages in the root of the content tree, if the Site has a template for them
 func (s *Site) index(src, dst *content.Dir, force bool) error {
+	if len(src.Pages) == 0 {
+		return nil
+	}
+	tmpl, err := s.tmpls.Root.Get(RootTemplate)
+	if err != nil {
+		log.Warnf("Missing %q template, skipping the root pages\n", RootTemplate)
+		return nil
+	}
+	root := &Dir{
+		Site:    s.Config,
+		Pages:   src.Pages,
+		name:    s.Config.Name,
+		url:     "/",
+		layout:  s.layout,
+		content: tmpl,
+		tmpls:   s.tmpls.Root,
+		outputs: s.Outputs,
+		root:    s,
+	}
+	return root.renderPages(src, dst, force)
+}
+
+// authors generates a listing page and feed for each configured Author
+func (s *Site) authors(src, dst *content.Tree) error {
+	if len(s.Config.Authors) == 0 {
+		return nil
+	}
+	tmpl, err := s.tmpls.Root.Get("author")
+	if err != nil {
+		log.Warnf("Missing \"author\" template, only generating author feeds\n")
+		tmpl = nil
+	}
+	dir, err := subDir(dst.Root, AuthorDir)
+	if err != nil {
+		return err
+	}
+	pages := src.Root.All()
+	for _, conf := range s.Config.Authors {
+		if err := NewAuthor(s, conf, tmpl).Render(pages, dir); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// notFound generates the page for missing files, if the Site has a template for it
+func (s *Site) notFound(dst *content.Dir) error {
+	tmpl, err := s.tmpls.Root.Get(s.Config.NotFound)
+	if err != nil {
+		log.Warnf("Missing %q template, skipping %s\n", s.Config.NotFound, NotFoundName)
+		return nil
+	}
+	return NewNotFound(s, tmpl).Render(dst)
+}
+
+// robots generates robots.txt, pointing crawlers at the sitemap
+func (s *Site) robots(dst *content.Dir) error {
+	var sitemap string
+	if s.Config.URL != "" {
+		sitemap = s.base.Abs("/" + SitemapName)
+	}
+	return writeFile(dst, "robots.txt", s.Config.Robots.Text(sitemap))
+}
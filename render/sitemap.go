@@ -0,0 +1,129 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package render
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/DataDrake/static-cling/content"
+	log "github.com/DataDrake/waterlog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	// SitemapName is the name of the sitemap, or sitemap index, in the root of the build tree
+	SitemapName = "sitemap.xml"
+	// SitemapLimit is the largest number of URLs allowed in a single sitemap
+	SitemapLimit = 50000
+	// sitemapNS is the XML namespace of the sitemap protocol
+	sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+)
+
+// sitemapURLSet is a single sitemap
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	NS      string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// sitemapURL is a single entry in a sitemap
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+	Priority   string `xml:"priority,omitempty"`
+}
+
+// sitemapIndex lists the sitemaps of a site which is too large for just one
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	NS       string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapRef `xml:"sitemap"`
+}
+
+// sitemapRef is a single entry in a sitemap index
+type sitemapRef struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// sitemap writes the sitemap for every Output, splitting it up with a sitemap index if it is too large
+//
+// Sitemaps must use absolute URLs, so there is no sitemap for a Site without a "url".
+func (s *Site) sitemap(dst *content.Dir) error {
+	if s.Config.URL == "" {
+		log.Warnln("Site has no \"url\" configured, skipping the sitemap")
+		if err := os.Remove(filepath.Join(dst.Path, SitemapName)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	var outs []*Output
+	var urls []sitemapURL
+	for _, out := range s.Outputs.List() {
+		if out.Sitemap.Exclude {
+			continue
+		}
+		outs = append(outs, out)
+		url := sitemapURL{
+			Loc:        s.base.Abs(out.URL),
+			ChangeFreq: out.Sitemap.ChangeFreq,
+		}
+		if !out.Modified.IsZero() {
+			url.LastMod = out.Modified.Format(time.RFC3339)
+		}
+		if out.Sitemap.Priority > 0 {
+			url.Priority = strconv.FormatFloat(out.Sitemap.Priority, 'f', 1, 64)
+		}
+		urls = append(urls, url)
+	}
+	if len(urls) <= SitemapLimit {
+		return writeXML(dst, SitemapName, sitemapURLSet{NS: sitemapNS, URLs: urls})
+	}
+	index := sitemapIndex{NS: sitemapNS}
+	for i := 0; i*SitemapLimit < len(urls); i++ {
+		end := (i + 1) * SitemapLimit
+		if end > len(urls) {
+			end = len(urls)
+		}
+		name := fmt.Sprintf("sitemap-%d.xml", i+1)
+		if err := writeXML(dst, name, sitemapURLSet{NS: sitemapNS, URLs: urls[i*SitemapLimit : end]}); err != nil {
+			return err
+		}
+		ref := sitemapRef{
+			Loc: s.base.Abs("/" + name),
+		}
+		if modified := newest(outs[i*SitemapLimit : end]); !modified.IsZero() {
+			ref.LastMod = modified.Format(time.RFC3339)
+		}
+		index.Sitemaps = append(index.Sitemaps, ref)
+	}
+	return writeXML(dst, SitemapName, index)
+}
+
+// newest gets the most recent modification time of a list of Outputs
+func newest(outs []*Output) (modified time.Time) {
+	for _, out := range outs {
+		if out.Modified.After(modified) {
+			modified = out.Modified
+		}
+	}
+	return
+}
@@ -0,0 +1,167 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package render
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/DataDrake/static-cling/config"
+	"github.com/DataDrake/static-cling/content"
+	"github.com/DataDrake/static-cling/templates"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newSitemapSite creates a Site with no templates, for rendering just the sitemap
+func newSitemapSite(url string) *Site {
+	return &Site{
+		Config:  &config.Site{URL: url},
+		Outputs: NewOutputs(),
+		base:    templates.NewBaseURL(url),
+	}
+}
+
+// newBuildDir creates an empty build directory
+func newBuildDir(t *testing.T) *content.Dir {
+	dst, err := content.NewDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dst
+}
+
+// readXML decodes an XML file from the build directory
+func readXML(t *testing.T, dst *content.Dir, name string, v interface{}) {
+	raw, err := os.ReadFile(filepath.Join(dst.Path, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = xml.Unmarshal(raw, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSitemapEntries(t *testing.T) {
+	dst := newBuildDir(t)
+	date := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	site := newSitemapSite("https://example.com/blog")
+	site.Outputs.add(dst, "index.html", "/", &content.Page{Date: date})
+	site.Outputs.add(dst, "draft.html", "/draft.html", &content.Page{
+		Sitemap: content.Sitemap{Exclude: true},
+	})
+	site.Outputs.add(dst, "about.html", "/about.html", &content.Page{
+		Sitemap: content.Sitemap{Priority: 0.8, ChangeFreq: "monthly"},
+	})
+	if err := site.sitemap(dst); err != nil {
+		t.Fatal(err)
+	}
+	var set sitemapURLSet
+	readXML(t, dst, SitemapName, &set)
+	want := []sitemapURL{
+		{Loc: "https://example.com/blog/", LastMod: "2021-03-04T05:06:07Z"},
+		{Loc: "https://example.com/blog/about.html", ChangeFreq: "monthly", Priority: "0.8"},
+	}
+	if len(set.URLs) != len(want) {
+		t.Fatalf("found %d URLs, want %d: %v", len(set.URLs), len(want), set.URLs)
+	}
+	for i, url := range set.URLs {
+		if url != want[i] {
+			t.Errorf("URL %d = %+v, want %+v", i, url, want[i])
+		}
+	}
+}
+
+func TestSitemapIndex(t *testing.T) {
+	dst := newBuildDir(t)
+	site := newSitemapSite("https://example.com")
+	first := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i <= SitemapLimit; i++ {
+		page := &content.Page{Date: first}
+		if i == SitemapLimit {
+			page.Date = last
+		}
+		// zero padded, so that Outputs are listed in the order they are added
+		name := fmt.Sprintf("%06d.html", i)
+		site.Outputs.add(dst, name, "/"+name, page)
+	}
+	if err := site.sitemap(dst); err != nil {
+		t.Fatal(err)
+	}
+	var index sitemapIndex
+	readXML(t, dst, SitemapName, &index)
+	want := []sitemapRef{
+		{Loc: "https://example.com/sitemap-1.xml", LastMod: "2021-01-01T00:00:00Z"},
+		{Loc: "https://example.com/sitemap-2.xml", LastMod: "2021-06-01T00:00:00Z"},
+	}
+	if len(index.Sitemaps) != len(want) {
+		t.Fatalf("found %d sitemaps, want %d", len(index.Sitemaps), len(want))
+	}
+	for i, ref := range index.Sitemaps {
+		if ref != want[i] {
+			t.Errorf("sitemap %d = %+v, want %+v", i, ref, want[i])
+		}
+	}
+	for i, size := range []int{SitemapLimit, 1} {
+		var set sitemapURLSet
+		readXML(t, dst, fmt.Sprintf("sitemap-%d.xml", i+1), &set)
+		if len(set.URLs) != size {
+			t.Errorf("sitemap-%d.xml has %d URLs, want %d", i+1, len(set.URLs), size)
+		}
+	}
+}
+
+func TestSitemapNoURL(t *testing.T) {
+	dst := newBuildDir(t)
+	stale := filepath.Join(dst.Path, SitemapName)
+	if err := os.WriteFile(stale, []byte("stale"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	site := newSitemapSite("")
+	site.Outputs.add(dst, "index.html", "/", &content.Page{})
+	if err := site.sitemap(dst); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("expected the stale sitemap to be removed, found: %v", err)
+	}
+}
+
+func TestRobots(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://example.com/blog", "User-agent: *\nDisallow: \n\nSitemap: https://example.com/blog/sitemap.xml\n"},
+		{"", "User-agent: *\nDisallow: \n"},
+	}
+	for _, test := range tests {
+		dst := newBuildDir(t)
+		if err := newSitemapSite(test.url).robots(dst); err != nil {
+			t.Fatal(err)
+		}
+		raw, err := os.ReadFile(filepath.Join(dst.Path, "robots.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(raw); got != test.want {
+			t.Errorf("robots.txt for %q = %q, want %q", test.url, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// timeType is decoded from a YAML timestamp, or a string in one of several layouts
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemerType is used to find types which implement Schemer
+var schemerType = reflect.TypeOf((*Schemer)(nil)).Elem()
+
+// generator builds a Schema for a type, collecting definitions of named structs as it goes
+type generator struct {
+	defs map[string]*Schema
+}
+
+// generate creates the root Schema for a type
+func generate(t reflect.Type) *Schema {
+	g := &generator{
+		defs: make(map[string]*Schema),
+	}
+	var s *Schema
+	if t.Kind() == reflect.Struct {
+		s = g.object(t)
+	} else {
+		s = g.schema(t)
+	}
+	s.Schema = Draft
+	s.Title = t.Name()
+	if len(g.defs) > 0 {
+		s.Defs = g.defs
+	}
+	return s
+}
+
+// schema creates a Schema for any type
+func (g *generator) schema(t reflect.Type) *Schema {
+	if t.Implements(schemerType) {
+		return reflect.Zero(t).Interface().(Schemer).JSONSchema()
+	}
+	if reflect.PtrTo(t).Implements(schemerType) {
+		return reflect.New(t).Interface().(Schemer).JSONSchema()
+	}
+	if t == timeType {
+		return &Schema{Type: "string"}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.schema(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.values(t.Elem())}
+	case reflect.Struct:
+		return g.ref(t)
+	default:
+		// interfaces may hold anything
+		return &Schema{}
+	}
+}
+
+// values creates the Schema for the values of a map, allowing anything for interfaces
+func (g *generator) values(t reflect.Type) interface{} {
+	if t.Kind() == reflect.Interface {
+		return true
+	}
+	return g.schema(t)
+}
+
+// ref creates a reference to the definition of a named struct, adding it if necessary
+func (g *generator) ref(t reflect.Type) *Schema {
+	name := t.Name()
+	if name == "" {
+		return g.object(t)
+	}
+	if _, ok := g.defs[name]; !ok {
+		// reserve the name first, in case the struct refers to itself
+		g.defs[name] = nil
+		g.defs[name] = g.object(t)
+	}
+	return &Schema{Ref: "#/$defs/" + name}
+}
+
+// object creates a Schema for the fields of a struct
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:                 "object",
+		Properties:           make(map[string]*Schema),
+		AdditionalProperties: false,
+	}
+	g.fields(s, t)
+	return s
+}
+
+// fields adds a property to a Schema for each field of a struct that is decoded from YAML
+func (g *generator) fields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		// like the YAML decoder, embedded structs are inlined even when unexported
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		name, opts := parseTag(field.Tag.Get("yaml"))
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "inline") {
+			switch ft := field.Type; ft.Kind() {
+			case reflect.Map:
+				s.AdditionalProperties = g.values(ft.Elem())
+			case reflect.Struct:
+				g.fields(s, ft)
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		s.Properties[name] = g.schema(field.Type)
+	}
+}
+
+// parseTag splits a YAML struct tag into its name and options
+func parseTag(tag string) (name, opts string) {
+	pieces := strings.SplitN(tag, ",", 2)
+	name = pieces[0]
+	if len(pieces) > 1 {
+		opts = pieces[1]
+	}
+	return
+}
@@ -0,0 +1,108 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// custom describes itself
+type custom struct{}
+
+// JSONSchema allows either a boolean or a string (satisfies Schemer)
+func (custom) JSONSchema() *Schema {
+	return &Schema{OneOf: []*Schema{{Type: "boolean"}, {Type: "string"}}}
+}
+
+// child is a named struct, which should become a definition
+type child struct {
+	Name     string `yaml:"name"`
+	Children []child
+}
+
+// extras are inlined into the struct holding them
+type extras struct {
+	Extra bool `yaml:"extra"`
+}
+
+// generated covers each kind of field the generator supports
+type generated struct {
+	Text    string                 `yaml:"text"`
+	Count   int                    `yaml:"count"`
+	Ratio   float64                `yaml:"ratio"`
+	Enabled *bool                  `yaml:"enabled,omitempty"`
+	Date    time.Time              `yaml:"date"`
+	Tags    []string               `yaml:"tags"`
+	Labels  map[string]string      `yaml:"labels"`
+	Values  map[string]interface{} `yaml:"values"`
+	Child   *child                 `yaml:"child"`
+	Custom  custom                 `yaml:"custom"`
+	Skipped string                 `yaml:"-"`
+	hidden  string
+	extras  `yaml:",inline"`
+}
+
+// encode gets the JSON encoding of a Schema, for comparison
+func encode(t *testing.T, s *Schema) string {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(raw)
+}
+
+func TestGenerate(t *testing.T) {
+	s := For(&generated{})
+	if s.Schema != Draft || s.Title != "generated" || s.Type != "object" || s.AdditionalProperties != false {
+		t.Errorf("unexpected root: %s", encode(t, s))
+	}
+	tests := map[string]string{
+		"text":    `{"type":"string"}`,
+		"count":   `{"type":"integer"}`,
+		"ratio":   `{"type":"number"}`,
+		"enabled": `{"type":"boolean"}`,
+		"date":    `{"type":"string"}`,
+		"tags":    `{"type":"array","items":{"type":"string"}}`,
+		"labels":  `{"type":"object","additionalProperties":{"type":"string"}}`,
+		"values":  `{"type":"object","additionalProperties":true}`,
+		"child":   `{"$ref":"#/$defs/child"}`,
+		"custom":  `{"oneOf":[{"type":"boolean"},{"type":"string"}]}`,
+		"extra":   `{"type":"boolean"}`,
+	}
+	if len(s.Properties) != len(tests) {
+		t.Errorf("found %d properties, want %d: %s", len(s.Properties), len(tests), encode(t, s))
+	}
+	for name, want := range tests {
+		prop, ok := s.Properties[name]
+		if !ok {
+			t.Errorf("missing property %q", name)
+			continue
+		}
+		if got := encode(t, prop); got != want {
+			t.Errorf("%s = %s, want %s", name, got, want)
+		}
+	}
+	want := `{"type":"object","properties":{"children":{"type":"array","items":{"$ref":"#/$defs/child"}},"name":{"type":"string"}},"additionalProperties":false}`
+	if got := encode(t, s.Defs["child"]); got != want {
+		t.Errorf("child = %s, want %s", got, want)
+	}
+	if For(generated{}) != s {
+		t.Error("expected the cached Schema for the same type")
+	}
+}
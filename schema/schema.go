@@ -0,0 +1,74 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package schema
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// Draft is the version of JSON Schema used by every Schema
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema describing a configuration or metadata file
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Schemer is implemented by types which describe their own Schema, usually because they have a custom YAML decoding
+type Schemer interface {
+	JSONSchema() *Schema
+}
+
+// cache holds the generated Schema for each type
+var cache = make(map[reflect.Type]*Schema)
+
+// cacheLock protects the cache
+var cacheLock sync.Mutex
+
+// For gets the Schema describing the YAML encoding of a value
+func For(v interface{}) *Schema {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	if s, ok := cache[t]; ok {
+		return s
+	}
+	s := generate(t)
+	cache[t] = s
+	return s
+}
+
+// Write encodes this Schema as indented JSON
+func (s *Schema) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
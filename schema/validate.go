@@ -0,0 +1,206 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package schema
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"strings"
+)
+
+// Error is a single place where a YAML document does not match a Schema
+type Error struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// Validate checks a YAML document against this Schema
+//
+// When strict, keys which are not part of the Schema are also reported.
+func (s *Schema) Validate(node *yaml.Node, strict bool) []Error {
+	v := &validator{
+		root:   s,
+		strict: strict,
+	}
+	v.validate(s, node, "")
+	return v.errs
+}
+
+// validator walks a YAML document alongside a Schema
+type validator struct {
+	root   *Schema
+	strict bool
+	errs   []Error
+}
+
+// fail records a new Error at a node, prefixed by the dotted path of the key it belongs to
+func (v *validator) fail(node *yaml.Node, key, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if key != "" {
+		msg = key + ": " + msg
+	}
+	v.errs = append(v.errs, Error{
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: msg,
+	})
+}
+
+// join appends a child to the dotted path of a key
+func join(key, child string) string {
+	if key == "" {
+		return child
+	}
+	return key + "." + child
+}
+
+// validate checks a single node, and its children, against a Schema
+func (v *validator) validate(s *Schema, node *yaml.Node, key string) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) > 0 {
+			v.validate(s, node.Content[0], key)
+		}
+		return
+	case yaml.AliasNode:
+		v.validate(s, node.Alias, key)
+		return
+	}
+	if node.ShortTag() == "!!null" {
+		// missing values are left as-is
+		return
+	}
+	if s.Ref != "" {
+		v.validate(v.root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")], node, key)
+		return
+	}
+	if len(s.OneOf) > 0 {
+		v.oneOf(s, node, key)
+		return
+	}
+	if !matches(s, node) {
+		v.fail(node, key, "expected %s, found %s", describeSchema(s), describeNode(node))
+		return
+	}
+	switch s.Type {
+	case "object":
+		v.object(s, node, key)
+	case "array":
+		for i, item := range node.Content {
+			v.validate(s.Items, item, fmt.Sprintf("%s[%d]", key, i))
+		}
+	}
+}
+
+// object checks each key of a mapping against the properties of a Schema
+func (v *validator) object(s *Schema, node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, value := node.Content[i], node.Content[i+1]
+		if prop, ok := s.Properties[name.Value]; ok {
+			v.validate(prop, value, join(key, name.Value))
+			continue
+		}
+		switch extra := s.AdditionalProperties.(type) {
+		case *Schema:
+			v.validate(extra, value, join(key, name.Value))
+		case bool:
+			if !extra && v.strict && name.Value != "<<" {
+				v.fail(name, key, "unknown key %q", name.Value)
+			}
+		}
+	}
+}
+
+// oneOf checks that a node matches at least one of the alternatives in a Schema
+func (v *validator) oneOf(s *Schema, node *yaml.Node, key string) {
+	var expected []string
+	for _, alt := range s.OneOf {
+		if !matches(alt, node) {
+			expected = append(expected, describeSchema(alt))
+			continue
+		}
+		// report problems for the first alternative of the right type
+		v.validate(alt, node, key)
+		return
+	}
+	v.fail(node, key, "expected %s, found %s", strings.Join(expected, " or "), describeNode(node))
+}
+
+// matches checks if the type of a node is compatible with a Schema
+//
+// Strings accept any scalar, just like the YAML decoder does.
+func matches(s *Schema, node *yaml.Node) bool {
+	switch s.Type {
+	case "object":
+		return node.Kind == yaml.MappingNode
+	case "array":
+		return node.Kind == yaml.SequenceNode
+	case "string":
+		return node.Kind == yaml.ScalarNode
+	case "integer":
+		return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!int"
+	case "number":
+		return node.Kind == yaml.ScalarNode && (node.ShortTag() == "!!int" || node.ShortTag() == "!!float")
+	case "boolean":
+		return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!bool"
+	default:
+		return true
+	}
+}
+
+// describeSchema names the type expected by a Schema
+func describeSchema(s *Schema) string {
+	switch s.Type {
+	case "object":
+		return "a mapping"
+	case "array":
+		return "a list"
+	case "integer":
+		return "an integer"
+	case "number":
+		return "a number"
+	case "boolean":
+		return "a boolean"
+	case "string":
+		return "a string"
+	default:
+		return "a value"
+	}
+}
+
+// describeNode names the type of a YAML node
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	switch node.ShortTag() {
+	case "!!int":
+		return "an integer"
+	case "!!float":
+		return "a number"
+	case "!!bool":
+		return "a boolean"
+	case "!!timestamp":
+		return "a date"
+	default:
+		return "a string"
+	}
+}
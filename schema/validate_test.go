@@ -0,0 +1,130 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package schema
+
+import (
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+// validated is the document checked by each validation test
+type validated struct {
+	Name    string            `yaml:"name"`
+	Count   int               `yaml:"count"`
+	Ratio   float64           `yaml:"ratio"`
+	Enabled bool              `yaml:"enabled"`
+	Date    string            `yaml:"date"`
+	Items   []child           `yaml:"items"`
+	Labels  map[string]string `yaml:"labels"`
+	Custom  custom            `yaml:"custom"`
+}
+
+// validate parses a YAML document and checks it against the Schema of validated
+func validate(t *testing.T, raw string, strict bool) []Error {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(raw), &node); err != nil {
+		t.Fatal(err)
+	}
+	return For(validated{}).Validate(&node, strict)
+}
+
+func TestValidateTypes(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"name: 10", ""},
+		{"name: 2021-01-01", ""},
+		{"count: 10", ""},
+		{"count: ten", "count: expected an integer, found a string"},
+		{"count: 1.5", "count: expected an integer, found a number"},
+		{"count: 2021-01-01", "count: expected an integer, found a date"},
+		{"ratio: 1", ""},
+		{"ratio: 1.5", ""},
+		{"ratio: yes please", "ratio: expected a number, found a string"},
+		{"enabled: true", ""},
+		{"enabled: 1", "enabled: expected a boolean, found an integer"},
+		{"date: 2021-01-01", ""},
+		{"date: [2021]", "date: expected a string, found a list"},
+		{"items: {name: x}", "items: expected a list, found a mapping"},
+		{"labels: [x]", "labels: expected a mapping, found a list"},
+		{"labels: {a: b}", ""},
+		{"custom: true", ""},
+		{"custom: text", ""},
+		{"custom: [x]", "custom: expected a boolean or a string, found a list"},
+		{"name: ~", ""},
+	}
+	for _, test := range tests {
+		errs := validate(t, test.raw, true)
+		switch {
+		case test.want == "" && len(errs) > 0:
+			t.Errorf("%q: unexpected errors: %v", test.raw, errs)
+		case test.want != "" && (len(errs) != 1 || errs[0].Message != test.want):
+			t.Errorf("%q: errors = %v, want %q", test.raw, errs, test.want)
+		}
+	}
+}
+
+func TestValidatePositions(t *testing.T) {
+	raw := `name: x
+items:
+    - name: first
+    - name: second
+      children:
+          - name: [third]
+count: many
+`
+	want := []Error{
+		{Line: 6, Column: 19, Message: "items[1].children[0].name: expected a string, found a list"},
+		{Line: 7, Column: 8, Message: "count: expected an integer, found a string"},
+	}
+	errs := validate(t, raw, true)
+	if len(errs) != len(want) {
+		t.Fatalf("found %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, err := range errs {
+		if err != want[i] {
+			t.Errorf("error %d = %+v, want %+v", i, err, want[i])
+		}
+	}
+}
+
+func TestValidateAdditionalProperties(t *testing.T) {
+	raw := "name: x\nnmae: y\nitems:\n    - extra: z\nlabels:\n    anything: goes\n"
+	tests := []struct {
+		strict bool
+		want   []Error
+	}{
+		{true, []Error{
+			{Line: 2, Column: 1, Message: `unknown key "nmae"`},
+			{Line: 4, Column: 7, Message: `items[0]: unknown key "extra"`},
+		}},
+		{false, nil},
+	}
+	for _, test := range tests {
+		errs := validate(t, raw, test.strict)
+		if len(errs) != len(test.want) {
+			t.Errorf("strict: %t, found %d errors, want %d: %v", test.strict, len(errs), len(test.want), errs)
+			continue
+		}
+		for i, err := range errs {
+			if err != test.want[i] {
+				t.Errorf("strict: %t, error %d = %+v, want %+v", test.strict, i, err, test.want[i])
+			}
+		}
+	}
+}
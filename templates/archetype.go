@@ -0,0 +1,117 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package templates
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	ttemplate "text/template"
+)
+
+// ArchetypeDir is the directory of a project which holds the archetypes for each section
+const ArchetypeDir = "archetypes"
+
+// defaultArchetype is used for sections without an archetype of their own
+var defaultArchetype = map[string]string{
+	".html": "<p>Write something here.</p>\n",
+	".yaml": `title: {{quote .Title}}
+{{- with .Author}}
+author: {{quote .}}
+{{- end}}
+date: {{dateFormat "2006-01-02T15:04:05Z07:00" .Date}}
+`,
+}
+
+// Archetype is a set of templates used to create new content, one per file extension
+type Archetype struct {
+	Path  string
+	files map[string]*ttemplate.Template
+}
+
+// LoadArchetype finds the archetype for a section, falling back to its ancestors and then the default
+//
+// The template functions resolve URLs against the "url" of the Site and read the assets of the project.
+func LoadArchetype(src, key, url string) (a *Archetype, err error) {
+	funcs := functions(NewBaseURL(url), Assets{{Path: filepath.Join(src, AssetDir)}})
+	for ; key != "." && key != "/" && key != ""; key = path.Dir(key) {
+		dir := filepath.Join(src, ArchetypeDir, filepath.FromSlash(key))
+		if a, err = readArchetype(dir, funcs); err == nil || !os.IsNotExist(err) {
+			return
+		}
+	}
+	a = &Archetype{
+		Path:  "default",
+		files: make(map[string]*ttemplate.Template),
+	}
+	for ext, raw := range defaultArchetype {
+		if a.files[ext], err = ttemplate.New(ext).Funcs(funcs).Parse(raw); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// readArchetype parses every file in an archetype directory, treating one without files as missing
+func readArchetype(dir string, funcs ttemplate.FuncMap) (a *Archetype, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	a = &Archetype{
+		Path:  dir,
+		files: make(map[string]*ttemplate.Template),
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(entry.Name())
+		if _, ok := a.files[ext]; ok {
+			err = fmt.Errorf("archetype %q has more than one %q file", dir, ext)
+			return
+		}
+		var raw []byte
+		if raw, err = os.ReadFile(filepath.Join(dir, entry.Name())); err != nil {
+			return
+		}
+		if a.files[ext], err = ttemplate.New(entry.Name()).Funcs(funcs).Parse(string(raw)); err != nil {
+			return
+		}
+	}
+	if len(a.files) == 0 {
+		err = os.ErrNotExist
+	}
+	return
+}
+
+// Exts lists the file extensions created by this Archetype, in order
+func (a *Archetype) Exts() (exts []string) {
+	for ext := range a.files {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	return
+}
+
+// Execute writes the file with the specified extension, using data to fill in the template
+func (a *Archetype) Execute(w io.Writer, ext string, data interface{}) error {
+	return a.files[ext].Execute(w, data)
+}
@@ -0,0 +1,84 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package templates
+
+import (
+	"bytes"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDefaultArchetype(t *testing.T) {
+	a, err := LoadArchetype(t.TempDir(), "posts", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := struct {
+		Title, Author string
+		Date          time.Time
+	}{
+		Title:  `Release: "big" # 1`,
+		Author: "me: myself",
+		Date:   time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC),
+	}
+	var buff bytes.Buffer
+	if err = a.Execute(&buff, ".yaml", data); err != nil {
+		t.Fatal(err)
+	}
+	var meta struct {
+		Title  string    `yaml:"title"`
+		Author string    `yaml:"author"`
+		Date   time.Time `yaml:"date"`
+	}
+	if err = yaml.Unmarshal(buff.Bytes(), &meta); err != nil {
+		t.Fatalf("invalid metadata %q: %s", buff.String(), err)
+	}
+	if meta.Title != data.Title || meta.Author != data.Author || !meta.Date.Equal(data.Date) {
+		t.Errorf("metadata = %+v, want %+v", meta, data)
+	}
+}
+
+func TestLoadArchetype(t *testing.T) {
+	src := t.TempDir()
+	dir := filepath.Join(src, ArchetypeDir, "docs")
+	if err := os.MkdirAll(filepath.Join(dir, "api"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "page.md"), []byte("# {{.}}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		key, path string
+	}{
+		{"docs", dir},
+		{"docs/api/v1", dir},
+		{"posts", "default"},
+	}
+	for _, test := range tests {
+		a, err := LoadArchetype(src, test.key, "")
+		if err != nil {
+			t.Errorf("LoadArchetype(%q) error: %s", test.key, err)
+			continue
+		}
+		if a.Path != test.path {
+			t.Errorf("LoadArchetype(%q) = %q, want %q", test.key, a.Path, test.path)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package templates
+
+import (
+	"errors"
+	"github.com/DataDrake/static-cling/file"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AssetDir is the relative directory for static assets
+const AssetDir = "assets"
+
+// Assets are the locations of the assets used by the "readFile" template function, in order of precedence
+type Assets []file.Source
+
+// ErrOutsideAssets is returned when "readFile" is asked for a file outside of the asset directory
+var ErrOutsideAssets = errors.New("readFile can only access files in the asset directory")
+
+// ReadFile reads the contents of a file in the first asset directory which has it
+func (a Assets) ReadFile(name string) (contents string, err error) {
+	clean := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		err = ErrOutsideAssets
+		return
+	}
+	var raw []byte
+	for _, dir := range a {
+		if raw, err = dir.ReadFile(filepath.ToSlash(clean)); err == nil || !os.IsNotExist(err) {
+			break
+		}
+	}
+	if err != nil {
+		return
+	}
+	contents = string(raw)
+	return
+}
@@ -0,0 +1,65 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package templates
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadFile(t *testing.T) {
+	project, theme := t.TempDir(), t.TempDir()
+	files := map[string]string{
+		filepath.Join(project, "css", "site.css"): "project",
+		filepath.Join(theme, "css", "site.css"):   "theme",
+		filepath.Join(theme, "css", "theme.css"):  "theme only",
+		filepath.Join(filepath.Dir(project), "x"): "outside",
+	}
+	for path, contents := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assets := Assets{{Path: project}, {Path: theme}}
+	tests := []struct {
+		name string
+		want string
+		err  error
+	}{
+		{"css/site.css", "project", nil},
+		{"/css/site.css", "", ErrOutsideAssets},
+		{"css/theme.css", "theme only", nil},
+		{"css/../css/theme.css", "theme only", nil},
+		{"../x", "", ErrOutsideAssets},
+		{"css/missing.css", "", os.ErrNotExist},
+	}
+	for _, test := range tests {
+		got, err := assets.ReadFile(test.name)
+		if !errors.Is(err, test.err) {
+			t.Errorf("readFile(%q) error = %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("readFile(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
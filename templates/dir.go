@@ -20,16 +20,22 @@ import (
 	"fmt"
 	"github.com/DataDrake/static-cling/file"
 	log "github.com/DataDrake/waterlog"
+	"os"
+	"path/filepath"
+	"strings"
+	ttemplate "text/template"
 )
 
 // Dir is a directory containing Template files
 type Dir struct {
 	*file.Dir
 	Templates map[string]Template
+	parent    *Dir
+	funcs     ttemplate.FuncMap
 }
 
-// NewDir creates a Dir from the specified path, recursively
-func NewDir(path string) (d *Dir, err error) {
+// NewDir creates a Dir from the specified path, recursively, with a FuncMap for its Templates
+func NewDir(funcs ttemplate.FuncMap, path string) (d *Dir, err error) {
 	dir, err := file.NewDir(path)
 	if err != nil {
 		return
@@ -37,6 +43,37 @@ func NewDir(path string) (d *Dir, err error) {
 	d = &Dir{
 		Dir:       dir,
 		Templates: make(map[string]Template),
+		funcs:     funcs,
+	}
+	err = d.Update(true)
+	return
+}
+
+// NewLayeredDir creates a Dir by overlaying several Sources, recursively, where earlier Sources take precedence
+//
+// Missing Sources are skipped, unless all of them are missing.
+func NewLayeredDir(funcs ttemplate.FuncMap, sources ...file.Source) (d *Dir, err error) {
+	var merged *file.Dir
+	for i := len(sources) - 1; i >= 0; i-- {
+		dir, dirErr := sources[i].Dir()
+		if dirErr != nil {
+			if !os.IsNotExist(dirErr) || (merged == nil && i == 0) {
+				return nil, dirErr
+			}
+			continue
+		}
+		if merged == nil {
+			merged = dir
+			continue
+		}
+		if merged, err = merged.Merge(dir, file.PreferRight); err != nil {
+			return
+		}
+	}
+	d = &Dir{
+		Dir:       merged,
+		Templates: make(map[string]Template),
+		funcs:     funcs,
 	}
 	err = d.Update(true)
 	return
@@ -52,17 +89,22 @@ func (d *Dir) Sub(name string) (next *Dir, err error) {
 	next = &Dir{
 		Dir:       dir,
 		Templates: make(map[string]Template),
+		funcs:     d.funcs,
 	}
 	err = next.Update(true)
 	return
 }
 
-// Get retrieves a specific template by name
+// Get retrieves a specific template by name, with or without its file extension
+//
+// Templates missing from a nested Section directory are retrieved from its ancestors instead.
 func (d *Dir) Get(name string) (tmpl Template, err error) {
-	tmpl, ok := d.Templates[name]
-	if !ok {
-		err = fmt.Errorf("failed to find template %q", name)
+	for dir := d; dir != nil; dir = dir.parent {
+		if tmpl, ok := dir.Templates[strings.TrimSuffix(name, filepath.Ext(name))]; ok {
+			return tmpl, nil
+		}
 	}
+	err = fmt.Errorf("failed to find template %q", name)
 	return
 }
 
@@ -93,7 +135,7 @@ func (d *Dir) Update(force bool) (err error) {
 			err = next.Update()
 		} else {
 			println(file.Path())
-			next, err = NewTemplate(file.Path())
+			next, err = NewTemplate(file, d.funcs)
 		}
 		if err != nil {
 			if err != ErrUnsupportedTemplate {
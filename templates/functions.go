@@ -17,17 +17,75 @@
 package templates
 
 import (
-	"strings"
-	"text/template"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	ttemplate "text/template"
 )
 
-var tmplFunctions template.FuncMap
+// functions provides the FuncMap shared by every supported template engine, bound to the URL and assets of a Site
+func functions(base BaseURL, assets Assets) ttemplate.FuncMap {
+	return ttemplate.FuncMap{
+		// strings
+		"toLower":     toLower,
+		"replace":     replace,
+		"quote":       quote,
+		"dateFormat":  dateFormat,
+		"markdownify": markdownify,
+		"truncate":    truncate,
+		"summary":     summary,
+		"slugify":     slugify,
+		// URLs and assets
+		"absURL":   base.Abs,
+		"relURL":   base.Rel,
+		"readFile": assets.ReadFile,
+		// content.Pages
+		"where":   where,
+		"sortBy":  sortBy,
+		"first":   first,
+		"groupBy": groupBy,
+		// helpers
+		"dict":     dict,
+		"list":     list,
+		"safeHTML": safeHTML,
+		"jsonify":  jsonify,
+	}
+}
+
+// ErrOddDict is returned when "dict" is called without a value for every key
+var ErrOddDict = errors.New("dict requires an even number of arguments")
 
-func functions() template.FuncMap {
-	if tmplFunctions == nil {
-		tmplFunctions = template.FuncMap{
-			"toLower": strings.ToLower,
+// dict builds a map from alternating keys and values
+func dict(pairs ...interface{}) (m map[string]interface{}, err error) {
+	if len(pairs)%2 != 0 {
+		err = ErrOddDict
+		return
+	}
+	m = make(map[string]interface{})
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			err = fmt.Errorf("dict keys must be strings, found %T", pairs[i])
+			return
 		}
+		m[key] = pairs[i+1]
 	}
-	return tmplFunctions
+	return
+}
+
+// list builds a list from its arguments, leaving the "slice" builtin alone
+func list(items ...interface{}) []interface{} {
+	return items
+}
+
+// safeHTML marks a string as trusted HTML which should not be escaped
+func safeHTML(s string) template.HTML {
+	return template.HTML(s)
+}
+
+// jsonify encodes a value as JSON
+func jsonify(v interface{}) (string, error) {
+	raw, err := json.Marshal(v)
+	return string(raw), err
 }
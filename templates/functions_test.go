@@ -0,0 +1,142 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package templates
+
+import (
+	"bytes"
+	"html/template"
+	"reflect"
+	"testing"
+)
+
+func TestDict(t *testing.T) {
+	tests := []struct {
+		pairs []interface{}
+		want  map[string]interface{}
+		err   bool
+	}{
+		{nil, map[string]interface{}{}, false},
+		{[]interface{}{"a", 1, "b", "two"}, map[string]interface{}{"a": 1, "b": "two"}, false},
+		{[]interface{}{"a"}, nil, true},
+		{[]interface{}{1, "a"}, nil, true},
+	}
+	for _, test := range tests {
+		got, err := dict(test.pairs...)
+		if (err != nil) != test.err {
+			t.Errorf("dict(%v) error = %v, want error: %t", test.pairs, err, test.err)
+			continue
+		}
+		if !test.err && !reflect.DeepEqual(got, test.want) {
+			t.Errorf("dict(%v) = %v, want %v", test.pairs, got, test.want)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	if got := list(1, "a", true); !reflect.DeepEqual(got, []interface{}{1, "a", true}) {
+		t.Errorf("list(1, \"a\", true) = %v", got)
+	}
+	if got := list(); len(got) != 0 {
+		t.Errorf("list() = %v, want empty", got)
+	}
+}
+
+func TestJSONify(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want string
+		err  bool
+	}{
+		{map[string]int{"a": 1}, `{"a":1}`, false},
+		{[]string{"x", "y"}, `["x","y"]`, false},
+		{make(chan int), "", true},
+	}
+	for _, test := range tests {
+		got, err := jsonify(test.in)
+		if (err != nil) != test.err {
+			t.Errorf("jsonify(%v) error = %v, want error: %t", test.in, err, test.err)
+			continue
+		}
+		if !test.err && got != test.want {
+			t.Errorf("jsonify(%v) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestFunctions(t *testing.T) {
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{`{{slice "abcdef" 1 3}}`, "bc"},
+		{`{{range list 1 2 3}}{{.}}{{end}}`, "123"},
+		{`{{with dict "a" "b"}}{{.a}}{{end}}`, "b"},
+		{`{{safeHTML "<b>x</b>"}}`, "<b>x</b>"},
+		{`{{"<b>x</b>"}}`, "&lt;b&gt;x&lt;/b&gt;"},
+		{`{{"Hello World" | toLower | slugify}}`, "hello-world"},
+		{`{{summary 2 .Content}}`, "one two…"},
+		{`{{truncate 7 .Content}}`, "one…"},
+		{`{{absURL "/docs/"}}`, "https://example.com/blog/docs/"},
+		{`{{relURL "css/site.css"}}`, "/blog/css/site.css"},
+	}
+	data := struct {
+		Content template.HTML
+	}{"<p>one <em>two</em> three</p>"}
+	for _, test := range tests {
+		tmpl, err := template.New("test").Funcs(template.FuncMap(functions("https://example.com/blog", nil))).Parse(test.tmpl)
+		if err != nil {
+			t.Errorf("failed to parse %q: %s", test.tmpl, err)
+			continue
+		}
+		var buff bytes.Buffer
+		if err = tmpl.Execute(&buff, data); err != nil {
+			t.Errorf("failed to execute %q: %s", test.tmpl, err)
+			continue
+		}
+		if got := buff.String(); got != test.want {
+			t.Errorf("%s = %q, want %q", test.tmpl, got, test.want)
+		}
+	}
+}
+
+func TestFunctionsPerSite(t *testing.T) {
+	// each FuncMap keeps its own base URL, rather than sharing the last one set
+	sites := []struct {
+		base, want string
+	}{
+		{"https://one.example.com", "https://one.example.com/a/"},
+		{"https://two.example.com/sub/", "https://two.example.com/sub/a/"},
+		{"", "/a/"},
+	}
+	var tmpls []*template.Template
+	for _, site := range sites {
+		tmpl, err := template.New("test").Funcs(template.FuncMap(functions(NewBaseURL(site.base), nil))).Parse(`{{absURL "a/"}}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tmpls = append(tmpls, tmpl)
+	}
+	for i, tmpl := range tmpls {
+		var buff bytes.Buffer
+		if err := tmpl.Execute(&buff, nil); err != nil {
+			t.Fatal(err)
+		}
+		if got := buff.String(); got != sites[i].want {
+			t.Errorf("base %q: absURL = %q, want %q", sites[i].base, got, sites[i].want)
+		}
+	}
+}
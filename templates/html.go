@@ -22,20 +22,24 @@ import (
 	"html/template"
 	"io"
 	"os"
-	"path/filepath"
+	ttemplate "text/template"
 )
 
 // HTML is a standard html/template
 type HTML struct {
 	file.File
-	tmpl *template.Template
+	tmpl  *template.Template
+	funcs template.FuncMap
 }
 
-// NewHTML creates a new HTML template from the file at the specified path
-func NewHTML(path string) (h *HTML, err error) {
-	h = &HTML{}
-	h.Init(filepath.Split(path))
-	log.Debugf("Creating template from %q\n", path)
+// NewHTML creates a new HTML template from a File, using a FuncMap of template functions
+func NewHTML(f *file.File, funcs ttemplate.FuncMap) (h *HTML, err error) {
+	h = &HTML{
+		funcs: template.FuncMap(funcs),
+	}
+	h.Init(f.Dir, f.Name+f.Ext)
+	h.FS = f.FS
+	log.Debugf("Creating template from %q\n", h.Path())
 	err = h.Update()
 	return
 }
@@ -45,13 +49,13 @@ func (h *HTML) Execute(out io.Writer, data interface{}) error {
 	return h.tmpl.Execute(out, data)
 }
 
-// Update re-reads the template from disk if it has changed
+// Update re-reads the template from disk if it has changed or has not been read yet
 func (h *HTML) Update() error {
 	changed, err := h.Stat()
 	if err != nil {
 		return err
 	}
-	if !changed {
+	if !changed && h.tmpl != nil {
 		return nil
 	}
 	if err := h.Open(os.O_RDONLY); err != nil {
@@ -62,6 +66,6 @@ func (h *HTML) Update() error {
 	if err != nil {
 		return err
 	}
-	h.tmpl, err = template.New(h.Name).Funcs(template.FuncMap(functions())).Parse(raw)
+	h.tmpl, err = template.New(h.Name).Funcs(h.funcs).Parse(raw)
 	return err
 }
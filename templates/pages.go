@@ -0,0 +1,43 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package templates
+
+import (
+	"github.com/DataDrake/static-cling/content"
+	"strings"
+)
+
+// where filters Pages to those where a field matches a value
+func where(pages content.Pages, key string, value interface{}) content.Pages {
+	return pages.Where(key, value)
+}
+
+// sortBy orders Pages by a field, in ascending order unless "desc" is specified
+func sortBy(pages content.Pages, key string, order ...string) content.Pages {
+	desc := len(order) > 0 && strings.ToLower(order[0]) == "desc"
+	return pages.SortBy(key, desc)
+}
+
+// first provides up to the first "n" Pages
+func first(n int, pages content.Pages) content.Pages {
+	return pages.Limit(n)
+}
+
+// groupBy splits Pages into groups by a field, in order of first appearance
+func groupBy(pages content.Pages, key string) content.PageGroups {
+	return pages.GroupBy(key)
+}
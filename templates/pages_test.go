@@ -0,0 +1,68 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package templates
+
+import (
+	"github.com/DataDrake/static-cling/content"
+	"testing"
+)
+
+// titles lists the title of each Page, in order
+func titles(pages content.Pages) (out []string) {
+	for _, page := range pages {
+		out = append(out, page.Title)
+	}
+	return
+}
+
+func TestPageFunctions(t *testing.T) {
+	pages := content.Pages{
+		{Title: "b", Weight: 2, Author: "x"},
+		{Title: "a", Weight: 3, Author: "y"},
+		{Title: "c", Weight: 1, Author: "x"},
+	}
+	tests := []struct {
+		name string
+		got  content.Pages
+		want []string
+	}{
+		{"where", where(pages, "author", "x"), []string{"b", "c"}},
+		{"where none", where(pages, "author", "z"), nil},
+		{"sortBy", sortBy(pages, "weight"), []string{"c", "b", "a"}},
+		{"sortBy desc", sortBy(pages, "title", "DESC"), []string{"c", "b", "a"}},
+		{"first", first(2, pages), []string{"b", "a"}},
+		{"first too many", first(5, pages), []string{"b", "a", "c"}},
+		{"first negative", first(-1, pages), nil},
+	}
+	for _, test := range tests {
+		got := titles(test.got)
+		if len(got) != len(test.want) {
+			t.Errorf("%s = %v, want %v", test.name, got, test.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("%s = %v, want %v", test.name, got, test.want)
+				break
+			}
+		}
+	}
+	groups := groupBy(pages, "author")
+	if len(groups) != 2 || groups[0].Key != "x" || len(groups[0].Pages) != 2 || groups[1].Key != "y" {
+		t.Errorf("groupBy(author) = %+v", groups)
+	}
+}
@@ -0,0 +1,116 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/DataDrake/static-cling/content"
+	"github.com/DataDrake/static-cling/util"
+	"github.com/russross/blackfriday/v2"
+	"html/template"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// replace substitutes every instance of old with new, eg. {{.Slug | replace "-" "."}}
+func replace(old, new, s string) string {
+	return strings.ReplaceAll(s, old, new)
+}
+
+// quote converts a value into a double-quoted string, which is safe to use in YAML, TOML and JSON
+func quote(v interface{}) (string, error) {
+	raw, err := json.Marshal(fmt.Sprint(v))
+	return string(raw), err
+}
+
+// toLower converts a string to lower-case
+func toLower(s string) string {
+	return strings.ToLower(s)
+}
+
+// dateFormat formats a date according to a Go time layout, accepting either a time.Time or an RFC3339 string
+func dateFormat(layout string, date interface{}) (out string, err error) {
+	var t time.Time
+	switch d := date.(type) {
+	case time.Time:
+		t = d
+	case *time.Time:
+		t = *d
+	case string:
+		if t, err = time.Parse(time.RFC3339, d); err != nil {
+			return
+		}
+	default:
+		err = fmt.Errorf("cannot format value of type %T as a date", date)
+		return
+	}
+	out = t.Format(layout)
+	return
+}
+
+// markdownify converts Markdown to HTML
+func markdownify(s string) template.HTML {
+	return template.HTML(blackfriday.Run([]byte(s)))
+}
+
+// ellipsis is appended to strings which have been shortened
+const ellipsis = "…"
+
+// plainText converts a value to a string for the text functions, removing any HTML from template.HTML like Page.Content
+func plainText(v interface{}) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case template.HTML:
+		return strings.Join(strings.Fields(content.PlainText(string(s))), " ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// truncate shortens text to at most "length" characters, breaking on a word boundary where possible
+func truncate(length int, v interface{}) string {
+	s := plainText(v)
+	if length < 0 {
+		length = 0
+	}
+	if utf8.RuneCountInString(s) <= length {
+		return s
+	}
+	runes := []rune(s)[:length]
+	if i := strings.LastIndexFunc(string(runes), unicode.IsSpace); i > 0 {
+		return strings.TrimRightFunc(string(runes)[:i], unicode.IsPunct) + ellipsis
+	}
+	return string(runes) + ellipsis
+}
+
+// summary provides the first "words" words of text, with any HTML removed
+func summary(words int, v interface{}) string {
+	fields := strings.Fields(content.PlainText(fmt.Sprint(v)))
+	if len(fields) <= words {
+		return strings.Join(fields, " ")
+	}
+	return strings.Join(fields[:words], " ") + ellipsis
+}
+
+// slugify converts a string into a lower-case, URL-friendly identifier
+func slugify(s string) string {
+	return util.Slugify(s)
+}
@@ -0,0 +1,153 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package templates
+
+import (
+	"html/template"
+	"testing"
+	"time"
+)
+
+func TestToLower(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"Hello World", "hello world"},
+		{"ÀÉÎ", "àéî"},
+	}
+	for _, test := range tests {
+		if got := toLower(test.in); got != test.want {
+			t.Errorf("toLower(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestReplace(t *testing.T) {
+	tests := []struct {
+		old, new, in, want string
+	}{
+		{"-", ".", "v1-1-0", "v1.1.0"},
+		{"x", "y", "abc", "abc"},
+		{"a", "", "banana", "bnn"},
+	}
+	for _, test := range tests {
+		if got := replace(test.old, test.new, test.in); got != test.want {
+			t.Errorf("replace(%q, %q, %q) = %q, want %q", test.old, test.new, test.in, got, test.want)
+		}
+	}
+}
+
+func TestDateFormat(t *testing.T) {
+	date := time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC)
+	tests := []struct {
+		layout string
+		date   interface{}
+		want   string
+		err    bool
+	}{
+		{"2006-01-02", date, "2021-03-04", false},
+		{"Jan 2, 2006", &date, "Mar 4, 2021", false},
+		{"15:04", "2021-03-04T05:06:07Z", "05:06", false},
+		{"2006", "March 4th", "", true},
+		{"2006", 2021, "", true},
+	}
+	for _, test := range tests {
+		got, err := dateFormat(test.layout, test.date)
+		if (err != nil) != test.err {
+			t.Errorf("dateFormat(%q, %v) error = %v, want error: %t", test.layout, test.date, err, test.err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("dateFormat(%q, %v) = %q, want %q", test.layout, test.date, got, test.want)
+		}
+	}
+}
+
+func TestMarkdownify(t *testing.T) {
+	tests := []struct {
+		in   string
+		want template.HTML
+	}{
+		{"", ""},
+		{"*hi*", "<p><em>hi</em></p>\n"},
+		{"# Title", "<h1>Title</h1>\n"},
+	}
+	for _, test := range tests {
+		if got := markdownify(test.in); got != test.want {
+			t.Errorf("markdownify(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		length int
+		in     interface{}
+		want   string
+	}{
+		{10, "short", "short"},
+		{5, "exact", "exact"},
+		{13, "Hello there, world", "Hello there…"},
+		{12, "Hello there, world", "Hello…"},
+		{4, "abcdefgh", "abcd…"},
+		{3, "héllo", "hél…"},
+		{0, "anything", "…"},
+		{-1, "anything", "…"},
+		{8, template.HTML("<p>Hello <em>there</em></p>"), "Hello…"},
+		{20, template.HTML("<p>Fish &amp; chips</p>"), "Fish & chips"},
+	}
+	for _, test := range tests {
+		if got := truncate(test.length, test.in); got != test.want {
+			t.Errorf("truncate(%d, %#v) = %q, want %q", test.length, test.in, got, test.want)
+		}
+	}
+}
+
+func TestSummary(t *testing.T) {
+	tests := []struct {
+		words int
+		in    interface{}
+		want  string
+	}{
+		{5, "one two three", "one two three"},
+		{2, "one two three", "one two…"},
+		{3, "<p>one <em>two</em></p>\n<p>three four</p>", "one two three…"},
+		{0, "one", "…"},
+		{2, template.HTML("<p>Fish &amp; chips</p>"), "Fish &…"},
+	}
+	for _, test := range tests {
+		if got := summary(test.words, test.in); got != test.want {
+			t.Errorf("summary(%d, %#v) = %q, want %q", test.words, test.in, got, test.want)
+		}
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Hello, World!", "hello-world"},
+		{"  Leading and trailing  ", "leading-and-trailing"},
+		{"v1.0.0", "v1-0-0"},
+	}
+	for _, test := range tests {
+		if got := slugify(test.in); got != test.want {
+			t.Errorf("slugify(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
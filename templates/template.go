@@ -21,6 +21,7 @@ import (
 	"github.com/DataDrake/static-cling/file"
 	"io"
 	"path/filepath"
+	ttemplate "text/template"
 )
 
 // Template represents any supported template type for content
@@ -36,11 +37,11 @@ type Template interface {
 // ErrUnsupportedTemplate indicates that the file type of the specified template is not supported
 var ErrUnsupportedTemplate = errors.New("template specified has unsupported extension")
 
-// NewTemplate creates a new Template from a file on disk
-func NewTemplate(path string) (Template, error) {
-	switch filepath.Ext(path) {
+// NewTemplate creates a new Template from a File, on disk or otherwise, using a FuncMap of template functions
+func NewTemplate(f *file.File, funcs ttemplate.FuncMap) (Template, error) {
+	switch filepath.Ext(f.Path()) {
 	case ".html":
-		return NewHTML(path)
+		return NewHTML(f, funcs)
 	case ".haml":
 		// return NewHAML(path)
 		fallthrough
@@ -17,8 +17,9 @@
 package templates
 
 import (
+	"github.com/DataDrake/static-cling/file"
 	log "github.com/DataDrake/waterlog"
-	"path/filepath"
+	"strings"
 )
 
 // Tree contains the full tree of Templates for this site
@@ -26,11 +27,21 @@ type Tree struct {
 	Root *Dir
 }
 
-// Load reads the template Tree from disk
-func Load(path string) (t *Tree, err error) {
+// Load reads the template Tree from disk, overlaying a project on any number of themes
+//
+// Templates and assets from the project take precedence over those of the themes, and earlier
+// themes over later ones. The template functions resolve URLs against the "url" of the Site.
+func Load(path, url string, themes ...file.Source) (t *Tree, err error) {
 	log.Debugln("Loading initial template tree")
+	project := file.Source{Path: path}
+	assets := Assets{project.Join(AssetDir)}
+	dirs := []file.Source{project.Join("templates")}
+	for _, theme := range themes {
+		assets = append(assets, theme.Join(AssetDir))
+		dirs = append(dirs, theme.Join("templates"))
+	}
 	t = &Tree{}
-	t.Root, err = NewDir(filepath.Join(path, "templates"))
+	t.Root, err = NewLayeredDir(functions(NewBaseURL(url), assets), dirs...)
 	return
 }
 
@@ -39,6 +50,29 @@ func (t *Tree) Sub(path string) (dir *Dir, err error) {
 	return t.Root.Sub(path)
 }
 
+// Section retrieves the templates for a Section by key, from the nearest directory along its path
+//
+// For example, "docs/api" uses "templates/docs/api" if it exists, falling back to "templates/docs"
+// for it or any template that it does not contain.
+func (t *Tree) Section(key string) (dir *Dir, err error) {
+	names := strings.Split(key, "/")
+	if dir, err = t.Root.Sub(names[0]); err != nil {
+		return
+	}
+	for _, name := range names[1:] {
+		if _, ok := dir.Dirs[name]; !ok {
+			break
+		}
+		var next *Dir
+		if next, err = dir.Sub(name); err != nil {
+			return
+		}
+		next.parent = dir
+		dir = next
+	}
+	return
+}
+
 // Update rereads the entire Tree
 func (t *Tree) Update(force bool) error {
 	return t.Root.Update(force)
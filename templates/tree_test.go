@@ -0,0 +1,77 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package templates
+
+import (
+	"bytes"
+	"github.com/DataDrake/static-cling/file"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTreeSection(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/layout.html":          {Data: []byte("layout")},
+		"templates/docs/post.html":       {Data: []byte("docs post")},
+		"templates/docs/list.html":       {Data: []byte("docs list")},
+		"templates/docs/api/post.html":   {Data: []byte("api post")},
+		"templates/docs/api/v1/ref.html": {Data: []byte("v1 ref")},
+	}
+	root, err := NewLayeredDir(functions("", nil), file.NewSource(fsys, "test").Join("templates"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := &Tree{Root: root}
+	tests := []struct {
+		key, name, want string
+	}{
+		{"docs", "post", "docs post"},
+		{"docs/api", "post", "api post"},
+		{"docs/api", "list", "docs list"},
+		{"docs/api/v1", "ref", "v1 ref"},
+		{"docs/api/v1", "post", "api post"},
+		{"docs/api/v1", "list", "docs list"},
+		{"docs/guide", "post", "docs post"},
+	}
+	for _, test := range tests {
+		dir, err := tree.Section(test.key)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.key, err)
+			continue
+		}
+		tmpl, err := dir.Get(test.name)
+		if err != nil {
+			t.Errorf("%s: failed to get %q: %s", test.key, test.name, err)
+			continue
+		}
+		var buff bytes.Buffer
+		if err = tmpl.Execute(&buff, nil); err != nil {
+			t.Fatal(err)
+		}
+		if got := buff.String(); got != test.want {
+			t.Errorf("%s: %q = %q, want %q", test.key, test.name, got, test.want)
+		}
+	}
+	if dir, err := tree.Section("docs"); err == nil {
+		if _, err = dir.Get("layout"); err == nil {
+			t.Error("a top-level section should not fall back to the root templates")
+		}
+	}
+	if _, err := tree.Section("blog"); err == nil {
+		t.Error("expected an error for a section without templates")
+	}
+}
@@ -0,0 +1,66 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package templates
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// BaseURL is the root URL of a Site, which the "absURL" and "relURL" template functions resolve paths against
+type BaseURL string
+
+// NewBaseURL creates a BaseURL from the "url" of a Site, which may be empty
+func NewBaseURL(url string) BaseURL {
+	return BaseURL(strings.TrimSuffix(url, "/"))
+}
+
+// isAbsolute checks if a URL already includes a scheme or host
+func isAbsolute(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme != "" || u.Host != "")
+}
+
+// Rel converts a site path into a URL relative to the site root, including any path in the base URL
+func (b BaseURL) Rel(raw string) string {
+	if isAbsolute(raw) {
+		return raw
+	}
+	prefix := "/"
+	if u, err := url.Parse(string(b)); err == nil {
+		prefix = path.Join("/", u.Path)
+	}
+	rel := path.Join(prefix, raw)
+	if strings.HasSuffix(raw, "/") && rel != "/" {
+		rel += "/"
+	}
+	return rel
+}
+
+// Abs converts a site path into a fully-qualified URL using the base URL
+func (b BaseURL) Abs(raw string) string {
+	if isAbsolute(raw) {
+		return raw
+	}
+	u, err := url.Parse(string(b))
+	if err != nil || u.Host == "" {
+		return b.Rel(raw)
+	}
+	u.Path = b.Rel(raw)
+	return u.String()
+}
@@ -0,0 +1,46 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package templates
+
+import (
+	"testing"
+)
+
+func TestURLs(t *testing.T) {
+	tests := []struct {
+		base, in, rel, abs string
+	}{
+		{"", "css/site.css", "/css/site.css", "/css/site.css"},
+		{"", "/docs/", "/docs/", "/docs/"},
+		{"", "/", "/", "/"},
+		{"https://example.com", "docs/", "/docs/", "https://example.com/docs/"},
+		{"https://example.com/", "/img/a.png", "/img/a.png", "https://example.com/img/a.png"},
+		{"https://example.com/blog", "posts/a.html", "/blog/posts/a.html", "https://example.com/blog/posts/a.html"},
+		{"https://example.com/blog/", "/", "/blog/", "https://example.com/blog/"},
+		{"https://example.com", "https://other.org/x", "https://other.org/x", "https://other.org/x"},
+		{"https://example.com", "//cdn.org/x.js", "//cdn.org/x.js", "//cdn.org/x.js"},
+	}
+	for _, test := range tests {
+		base := NewBaseURL(test.base)
+		if got := base.Rel(test.in); got != test.rel {
+			t.Errorf("Rel(%q) with base %q = %q, want %q", test.in, test.base, got, test.rel)
+		}
+		if got := base.Abs(test.in); got != test.abs {
+			t.Errorf("Abs(%q) with base %q = %q, want %q", test.in, test.base, got, test.abs)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package themes
+
+import (
+	"embed"
+	"github.com/DataDrake/static-cling/file"
+	"io/fs"
+	"sort"
+)
+
+// builtin holds every theme compiled into the binary, each in a directory of its own
+//
+// Files starting with "_" must be listed explicitly to be embedded.
+//
+//go:embed starter starter/config/_site.yaml
+var builtin embed.FS
+
+// Get retrieves a builtin theme by name
+func Get(name string) (src file.Source, ok bool) {
+	info, err := fs.Stat(builtin, name)
+	if err != nil || !info.IsDir() {
+		return
+	}
+	src = file.NewSource(builtin, "builtin").Join(name)
+	ok = true
+	return
+}
+
+// Names lists every builtin theme
+func Names() (names []string) {
+	entries, _ := fs.ReadDir(builtin, ".")
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return
+}
@@ -0,0 +1,63 @@
+//
+// Copyright 2021 Bryan T. Meyers <bmeyers@datadrake.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package themes
+
+import (
+	"io/fs"
+	"testing"
+)
+
+func TestGetStarter(t *testing.T) {
+	src, ok := Get("starter")
+	if !ok {
+		t.Fatal("missing the builtin \"starter\" theme")
+	}
+	if got := src.String(); got != "builtin:starter" {
+		t.Errorf("source = %q, want %q", got, "builtin:starter")
+	}
+	for _, name := range []string{"config/_site.yaml", "templates/layout.html", "templates/page.html", "templates/404.html"} {
+		if _, err := src.ReadFile(name); err != nil {
+			t.Errorf("failed to read %q, reason: %s", name, err)
+		}
+	}
+	dir, err := src.Join("templates").Dir()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := dir.Files["layout.html"]; !ok {
+		t.Error("missing \"layout.html\" in the templates directory")
+	}
+	fsys, err := src.Sub()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err = fs.Stat(fsys, "assets"); err != nil {
+		t.Errorf("missing assets, reason: %s", err)
+	}
+}
+
+func TestGetMissing(t *testing.T) {
+	for _, name := range []string{"missing", "starter/templates/layout.html", ""} {
+		if _, ok := Get(name); ok {
+			t.Errorf("Get(%q) found a theme", name)
+		}
+	}
+	names := Names()
+	if len(names) == 0 || names[0] != "starter" {
+		t.Errorf("names = %v, want to include starter", names)
+	}
+}
@@ -19,6 +19,7 @@ package util
 import (
 	log "github.com/DataDrake/waterlog"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 )
@@ -75,3 +76,30 @@ func CopyDir(srcDir, dstDir string) {
 		return nil
 	})
 }
+
+// CopyFS copies the full contents of a filesystem to a directory
+func CopyFS(fsys fs.FS, dstDir string) {
+	fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == "." {
+			return nil
+		}
+		dstPath := filepath.Join(dstDir, filepath.FromSlash(path))
+		if d.IsDir() {
+			CreateDir(dstPath)
+			return nil
+		}
+		log.Infof("Copying '%s' to '%s'\n", path, dstPath)
+		raw, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			log.Fatalf("Failed to read source file '%s', reason: %s\n", path, err)
+		}
+		if err = os.WriteFile(dstPath, raw, 0644); err != nil {
+			log.Fatalf("Failed to write destination file '%s', reason: %s\n", dstPath, err)
+		}
+		log.Goodln("Done.")
+		return nil
+	})
+}